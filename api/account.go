@@ -8,10 +8,13 @@ import (
 	"github.com/gin-gonic/gin"
 	db "github.com/leedrum/simplebank/db/sqlc"
 	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
 )
 
 type CreateAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// Product is checking when empty
+	Product string `json:"product" binding:"omitempty,alphanum"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -21,11 +24,26 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
+	product := util.AccountProductChecking
+	if req.Product != "" {
+		if _, err := server.store.GetAccountProduct(ctx, req.Product); err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				ctx.JSON(http.StatusBadRequest, errorHandler(fmt.Errorf("unknown account product %q", req.Product)))
+				return
+			}
+
+			ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+			return
+		}
+		product = req.Product
+	}
+
 	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
 	arg := db.CreateAccountParams{
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
 		Balance:  0,
+		Product:  product,
 	}
 
 	account, err := server.store.CreateAccount(ctx, arg)
@@ -107,52 +125,61 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, accounts)
 }
 
-type updateAccountRequest struct {
-	Balance int64 `json:"balance" binding:"required"`
+type adjustBalanceRequest struct {
+	Amount     int64  `json:"amount" binding:"required"`
+	ReasonCode string `json:"reason_code" binding:"required,adjustment_reason"`
+	Note       string `json:"note" binding:"max=255"`
 }
 
-func (server *Server) updateAccount(ctx *gin.Context) {
-	var req_get getAccountRequest
-	if err := ctx.ShouldBindUri(&req_get); err != nil {
+// adjustBalance lets a banker correct an account's balance.
+// The change is booked against the bank's suspense account so the ledger stays balanced.
+func (server *Server) adjustBalance(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	var reqAccount getAccountRequest
+	if err := ctx.ShouldBindUri(&reqAccount); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorHandler(err))
 		return
 	}
 
-	var req_update updateAccountRequest
-	if err := ctx.ShouldBindJSON(&req_update); err != nil {
+	var req adjustBalanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorHandler(err))
 		return
 	}
 
-	arg := db.UpdateAccountParams{
-		ID:      req_get.ID,
-		Balance: req_update.Balance,
+	arg := db.AdjustBalanceTxParams{
+		AccountID:  reqAccount.ID,
+		Amount:     req.Amount,
+		ReasonCode: req.ReasonCode,
+		Note:       req.Note,
+		CreatedBy:  authPayload.Username,
 	}
 
-	_, err := server.store.GetAccount(ctx, arg.ID)
+	result, err := server.store.AdjustBalanceTx(ctx, arg)
 	if err != nil {
 		if errors.Is(err, db.ErrorRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, errorHandler(err))
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
-		return
-	}
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorHandler(err))
+			return
+		}
 
-	account, err := server.store.UpdateAccount(ctx, arg)
-	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusCreated, result)
 }
 
 type deleteAccountRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+// deleteAccount closes an account of the caller, which requires a zero balance.
+// The account is kept with its entries and transfers, and a banker can reopen it.
 func (server *Server) deleteAccount(ctx *gin.Context) {
 	var req deleteAccountRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -160,7 +187,7 @@ func (server *Server) deleteAccount(ctx *gin.Context) {
 		return
 	}
 
-	_, err := server.store.GetAccount(ctx, req.ID)
+	account, err := server.store.GetAccount(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, db.ErrorRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, errorHandler(err))
@@ -171,11 +198,85 @@ func (server *Server) deleteAccount(ctx *gin.Context) {
 		return
 	}
 
-	err = server.store.DeleteAccount(ctx, req.ID)
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	if account.Owner != authPayload.Username && !authPayload.HasPermission(token.PermissionAccountsAdmin) {
+		err := fmt.Errorf("account [%d] does not belong to the authenticated user", account.ID)
+		ctx.JSON(http.StatusForbidden, errorHandler(err))
+		return
+	}
+
+	arg := db.ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    util.AccountStatusClosed,
+		ChangedBy: authPayload.Username,
+	}
+	// only a banker can close a frozen account, so the owner cannot get around the freeze
+	if !authPayload.HasPermission(token.PermissionAccountsAdmin) {
+		if account.Status != util.AccountStatusActive {
+			err := &db.AccountNotActiveError{AccountID: account.ID, Status: account.Status}
+			ctx.JSON(http.StatusForbidden, errorHandler(err))
+			return
+		}
+		arg.FromStatus = util.AccountStatusActive
+	}
+
+	server.changeAccountStatus(ctx, arg)
+}
+
+type updateAccountStatusRequest struct {
+	Status string `json:"status" binding:"required,account_status"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// updateAccountStatus lets a banker freeze, unfreeze, close or reopen an account
+func (server *Server) updateAccountStatus(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	var reqAccount getAccountRequest
+	if err := ctx.ShouldBindUri(&reqAccount); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	var req updateAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	server.changeAccountStatus(ctx, db.ChangeAccountStatusTxParams{
+		AccountID: reqAccount.ID,
+		Status:    req.Status,
+		Reason:    req.Reason,
+		ChangedBy: authPayload.Username,
+	})
+}
+
+func (server *Server) changeAccountStatus(ctx *gin.Context, arg db.ChangeAccountStatusTxParams) {
+	result, err := server.store.ChangeAccountStatusTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountStatusTransition) {
+			ctx.JSON(http.StatusConflict, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountBalanceNotZero) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusForbidden, errorHandler(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, nil)
+	ctx.JSON(http.StatusOK, result)
 }
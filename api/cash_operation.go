@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+)
+
+type cashOperationRequest struct {
+	Amount    int64  `json:"amount" binding:"required,gt=0"`
+	Reference string `json:"reference" binding:"required,reference"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+// deposit lets a banker credit an account with money received by the bank, against the bank's cash account
+func (server *Server) deposit(ctx *gin.Context) {
+	server.recordCashOperation(ctx, server.store.DepositTx)
+}
+
+// withdraw lets a banker debit an account with money paid out by the bank, against the bank's cash account
+func (server *Server) withdraw(ctx *gin.Context) {
+	server.recordCashOperation(ctx, server.store.WithdrawTx)
+}
+
+func (server *Server) recordCashOperation(
+	ctx *gin.Context,
+	operationTx func(context.Context, db.CashOperationTxParams) (db.CashOperationTxResult, error),
+) {
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	var reqAccount getAccountRequest
+	if err := ctx.ShouldBindUri(&reqAccount); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	var req cashOperationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	arg := db.CashOperationTxParams{
+		AccountID: reqAccount.ID,
+		Amount:    req.Amount,
+		Reference: req.Reference,
+		Note:      req.Note,
+		CreatedBy: authPayload.Username,
+	}
+
+	result, err := operationTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrReferenceAlreadyUsed) {
+			ctx.JSON(http.StatusConflict, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorHandler(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	mockdb "github.com/leedrum/simplebank/db/mock"
 	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/revocation"
 	"github.com/leedrum/simplebank/util"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestMain(m *testing.M) {
@@ -23,7 +26,15 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	// tokens are not revoked, unless a test expects the revocation lookup beforehand
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			GetTokenRevocation(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.GetTokenRevocationRow{}, nil)
+	}
+
+	server, err := NewServer(config, store, revocation.NewList(store, time.Minute, nil))
 	require.NoError(t, err)
 
 	return server
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/leedrum/simplebank/revocation"
 	"github.com/leedrum/simplebank/token"
 )
 
@@ -14,7 +16,7 @@ const (
 	authorizationPayload    = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revocations *revocation.List) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the value of the Authorization header
 		authorization := c.GetHeader(authorizationHeaderKey)
@@ -40,8 +42,37 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		revoked, err := revocations.IsRevoked(c, payload)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorHandler(err))
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			c.Abort()
+			return
+		}
+
 		c.Set(authorizationPayload, payload)
 
 		c.Next()
 	}
 }
+
+// requirePermission rejects the requests whose access token does not grant the permission,
+// it must run after authMiddleware
+func requirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload := c.MustGet(authorizationPayload).(*token.Payload)
+		if !payload.HasPermission(permission) {
+			err := fmt.Errorf("access token does not grant %s", permission)
+			c.JSON(http.StatusForbidden, errorHandler(err))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,9 +9,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
 	"github.com/leedrum/simplebank/token"
 	"github.com/leedrum/simplebank/util"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func AddAuthorization(
@@ -21,7 +25,19 @@ func AddAuthorization(
 	username string,
 	duration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateToken(username, util.DepositorRole, duration)
+	AddAuthorizationWithRole(t, request, tokenMaker, authorizationType, username, util.DepositorRole, duration)
+}
+
+func AddAuthorizationWithRole(
+	t *testing.T,
+	request *http.Request,
+	tokenMaker token.Maker,
+	authorizationType string,
+	username string,
+	role string,
+	duration time.Duration,
+) {
+	token, payload, err := tokenMaker.CreateToken(username, role, rolePermissions(role), duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -29,10 +45,30 @@ func AddAuthorization(
 	request.Header.Set("Authorization", authorizationHeader)
 }
 
+// rolePermissions returns the permissions granted to role by the migrations
+func rolePermissions(role string) []string {
+	if role == util.BankerRole {
+		return token.AllPermissions
+	}
+
+	return []string{
+		token.PermissionAccountsRead,
+		token.PermissionAccountsWrite,
+		token.PermissionTransfersRead,
+		token.PermissionTransfersWrite,
+		token.PermissionSettingsRead,
+		token.PermissionSessionsRead,
+		token.PermissionSessionsWrite,
+		token.PermissionUsersWrite,
+		token.PermissionTokensWrite,
+	}
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	testCases := []struct {
 		name          string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *http.Response)
 	}{
 		{
@@ -40,6 +76,8 @@ func TestAuthMiddleware(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusOK, recorder.StatusCode)
 			},
@@ -48,6 +86,8 @@ func TestAuthMiddleware(t *testing.T) {
 			name: "NoAuthorization",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
 			},
@@ -57,6 +97,8 @@ func TestAuthMiddleware(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, "UnSupported", "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
 			},
@@ -66,6 +108,8 @@ func TestAuthMiddleware(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, "", "user", time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
 			},
@@ -75,6 +119,8 @@ func TestAuthMiddleware(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "", -time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
 			},
@@ -84,19 +130,72 @@ func TestAuthMiddleware(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", -time.Minute)
 			},
+			buildStubs: func(store *mockdb.MockStore) {
+			},
 			checkResponse: func(t *testing.T, recorder *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
 			},
 		},
+		{
+			name: "RevokedToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenRevocation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenRevocationRow{Revoked: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
+			},
+		},
+		{
+			name: "TokenIssuedBeforeCutoff",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenRevocation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenRevocationRow{NotBefore: time.Now().Add(time.Second)}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, recorder.StatusCode)
+			},
+		},
+		{
+			name: "RevocationLookupError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				AddAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenRevocation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenRevocationRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, recorder.StatusCode)
+			},
+		},
 	}
 
 	for i := range testCases {
 		tc := testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
 
 			auth_path := "/auth"
-			server.router.GET(auth_path, authMiddleware(server.tokenMaker), func(ctx *gin.Context) {
+			server.router.GET(auth_path, authMiddleware(server.tokenMaker, server.revocations), func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			})
 
@@ -111,3 +210,66 @@ func TestAuthMiddleware(t *testing.T) {
 
 	}
 }
+
+func TestRequirePermission(t *testing.T) {
+	testCases := []struct {
+		name          string
+		permissions   []string
+		checkResponse func(t *testing.T, recorder *http.Response)
+	}{
+		{
+			name:        "OK",
+			permissions: []string{token.PermissionAccountsRead, token.PermissionTransfersRead},
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusOK, recorder.StatusCode)
+			},
+		},
+		{
+			name:        "PermissionNotGranted",
+			permissions: []string{token.PermissionAccountsRead},
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusForbidden, recorder.StatusCode)
+			},
+		},
+		{
+			name:        "NoPermission",
+			permissions: nil,
+			checkResponse: func(t *testing.T, recorder *http.Response) {
+				require.Equal(t, http.StatusForbidden, recorder.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			auth_path := "/auth"
+			server.router.GET(
+				auth_path,
+				authMiddleware(server.tokenMaker, server.revocations),
+				requirePermission(token.PermissionTransfersRead),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, auth_path, nil)
+			require.NoError(t, err)
+
+			// a token down-scoped to some of the permissions of the user
+			accessToken, _, err := server.tokenMaker.CreateToken("user", util.BankerRole, tc.permissions, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder.Result())
+		})
+	}
+}
@@ -7,31 +7,46 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/revocation"
 	"github.com/leedrum/simplebank/token"
 	"github.com/leedrum/simplebank/util"
 )
 
 type Server struct {
-	config     util.Config
-	store      db.Store
-	router     *gin.Engine
-	tokenMaker token.Maker
+	config      util.Config
+	store       db.Store
+	router      *gin.Engine
+	tokenMaker  token.Maker
+	revocations *revocation.List
 }
 
-func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, revocations *revocation.List) (*Server, error) {
+	keyring, err := token.LoadKeyring(config.TokenKeyringFile, config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load token keyring: %w", err)
+	}
+
+	tokenMaker, err := token.NewMaker(config.TokenMaker, keyring, token.Claims{
+		Issuer:   config.TokenIssuer,
+		Audience: config.TokenAudience,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		revocations: revocations,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("adjustment_reason", validAdjustmentReason)
+		v.RegisterValidation("reversal_reason", validReversalReason)
+		v.RegisterValidation("reference", validReference)
+		v.RegisterValidation("account_status", validAccountStatus)
 	}
 
 	server.setupRouter()
@@ -43,15 +58,23 @@ func (server *Server) setupRouter() {
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
 	router.POST("/users/renew", server.renewAccessToken)
+	router.POST("/users/logout", server.logoutUser)
 
-	authRoute := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoute := router.Group("/").Use(authMiddleware(server.tokenMaker, server.revocations))
 
-	authRoute.POST("/accounts", server.createAccount)
-	authRoute.GET("/accounts/:id", server.getAccount)
-	authRoute.GET("/accounts", server.listAccounts)
-	authRoute.PUT("/accounts/:id", server.updateAccount)
-	authRoute.DELETE("/accounts/:id", server.deleteAccount)
-	authRoute.POST("/transfers", server.createTransfer)
+	authRoute.POST("/accounts", requirePermission(token.PermissionAccountsWrite), server.createAccount)
+	authRoute.GET("/accounts/:id", requirePermission(token.PermissionAccountsRead), server.getAccount)
+	authRoute.GET("/accounts", requirePermission(token.PermissionAccountsRead), server.listAccounts)
+	authRoute.POST("/accounts/:id/adjustments", requirePermission(token.PermissionAccountsAdmin), server.adjustBalance)
+	authRoute.POST("/accounts/:id/deposits", requirePermission(token.PermissionAccountsAdmin), server.deposit)
+	authRoute.POST("/accounts/:id/withdrawals", requirePermission(token.PermissionAccountsAdmin), server.withdraw)
+	authRoute.PATCH("/accounts/:id/status", requirePermission(token.PermissionAccountsAdmin), server.updateAccountStatus)
+	authRoute.DELETE("/accounts/:id", requirePermission(token.PermissionAccountsWrite), server.deleteAccount)
+	authRoute.POST("/transfers", requirePermission(token.PermissionTransfersWrite), server.createTransfer)
+	authRoute.POST("/transfers/:id/reversals", requirePermission(token.PermissionTransfersAdmin), server.reverseTransfer)
+	authRoute.GET("/sessions", requirePermission(token.PermissionSessionsRead), server.listSessions)
+	authRoute.DELETE("/sessions/:id", requirePermission(token.PermissionSessionsWrite), server.revokeSession)
+	authRoute.DELETE("/sessions", requirePermission(token.PermissionSessionsWrite), server.revokeAllSessions)
 
 	server.router = router
 }
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+)
+
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ClientIP  string    `json:"client_ip"`
+	UserAgent string    `json:"user_agent"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// newSessionResponse leaves out the refresh token of the session
+func newSessionResponse(session db.Session) sessionResponse {
+	return sessionResponse{
+		ID:        session.ID,
+		Username:  session.Username,
+		ClientIP:  session.ClientIp,
+		UserAgent: session.UserAgent,
+		ExpiresAt: session.ExpiresAt,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+// sessionOwner returns whose sessions a request is about: the authenticated user
+// when username is empty, anyone else only with the users:admin permission
+func sessionOwner(authPayload *token.Payload, username string) (string, error) {
+	if username == "" || username == authPayload.Username {
+		return authPayload.Username, nil
+	}
+
+	if !authPayload.HasPermission(token.PermissionUsersAdmin) {
+		return "", fmt.Errorf("cannot manage the sessions of other users")
+	}
+
+	return username, nil
+}
+
+type listSessionsRequest struct {
+	Username string `form:"username" binding:"omitempty,alphanum"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listSessions(ctx *gin.Context) {
+	var req listSessionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	username, err := sessionOwner(authPayload, req.Username)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorHandler(err))
+		return
+	}
+
+	sessions, err := server.store.ListActiveSessions(ctx, db.ListActiveSessionsParams{
+		Username: username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	rsp := make([]sessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		rsp = append(rsp, newSessionResponse(session))
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type revokeSessionRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) revokeSession(ctx *gin.Context) {
+	var req revokeSessionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	sessionID := uuid.MustParse(req.ID)
+	session, err := server.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorHandler(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	if _, err := sessionOwner(authPayload, session.Username); err != nil {
+		ctx.JSON(http.StatusForbidden, errorHandler(err))
+		return
+	}
+
+	// blocking the family also blocks the refresh tokens the session was rotated from
+	if _, err := server.store.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	if err := server.revocations.RevokeSessionTokens(ctx, session.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSessionResponse(session))
+}
+
+type revokeAllSessionsRequest struct {
+	Username string `form:"username" binding:"omitempty,alphanum"`
+}
+
+type revokeAllSessionsResponse struct {
+	RevokedCount int64 `json:"revoked_count"`
+}
+
+func (server *Server) revokeAllSessions(ctx *gin.Context) {
+	var req revokeAllSessionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	username, err := sessionOwner(authPayload, req.Username)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorHandler(err))
+		return
+	}
+
+	revoked, err := server.store.BlockUserSessions(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	// the access tokens already issued are rejected too, which locks the user out until the next login
+	if err := server.revocations.RevokeUserTokens(ctx, username, time.Now()); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revokeAllSessionsResponse{RevokedCount: revoked})
+}
+
+type logoutUserRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// logoutUser blocks the session of the refresh token and the ones rotated with it, so none can be renewed anymore,
+// and revokes the access tokens issued with them
+func (server *Server) logoutUser(ctx *gin.Context) {
+	var req logoutUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			ctx.JSON(http.StatusUnauthorized, errorHandler(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	if session.Username != refreshPayload.Username || session.RefreshToken != req.RefreshToken {
+		err := fmt.Errorf("mismatched session token")
+		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
+		return
+	}
+
+	if _, err := server.store.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	if err := server.revocations.RevokeSessionTokens(ctx, session.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, refreshToken string, payload *token.Payload)
+		checkResponse func(recorder *httptest.ResponseRecorder, refreshToken string)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string, payload *token.Payload) {
+				store.EXPECT().
+					ListRolePermissions(gomock.Any(), gomock.Eq(util.DepositorRole)).
+					Times(1).
+					Return(rolePermissions(util.DepositorRole), nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						require.Equal(t, payload.ID, arg.SessionID)
+						require.Equal(t, refreshToken, arg.RefreshToken)
+
+						session := db.Session{ID: payload.ID, FamilyID: payload.ID, ExpiresAt: payload.ExpiredAt}
+						newSession, err := arg.IssueRefreshToken(session)
+						require.NoError(t, err)
+						require.WithinDuration(t, payload.ExpiredAt, newSession.ExpiresAt, time.Second)
+
+						return db.RotateSessionTxResult{Session: db.Session{ID: newSession.ID}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp renewAccessTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.NotEmpty(t, rsp.AccessToken)
+				require.NotEmpty(t, rsp.RefreshToken)
+				require.NotEqual(t, refreshToken, rsp.RefreshToken)
+				require.WithinDuration(t, time.Now().Add(time.Minute), rsp.AccessTokenExpiresAt, 5*time.Second)
+			},
+		},
+		{
+			name: "BlockedSession",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string, payload *token.Payload) {
+				store.EXPECT().
+					ListRolePermissions(gomock.Any(), gomock.Eq(util.DepositorRole)).
+					Times(1).
+					Return(rolePermissions(util.DepositorRole), nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrSessionBlocked)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RefreshTokenReused",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string, payload *token.Payload) {
+				store.EXPECT().
+					ListRolePermissions(gomock.Any(), gomock.Eq(util.DepositorRole)).
+					Times(1).
+					Return(rolePermissions(util.DepositorRole), nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, db.ErrRefreshTokenReused)
+
+				store.EXPECT().
+					RevokeSessionTokens(gomock.Any(), gomock.Eq(payload.ID)).
+					Times(1).
+					Return([]db.RevokedToken{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			refreshToken, payload, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, nil, time.Hour)
+			require.NoError(t, err)
+			tc.buildStubs(store, refreshToken, payload)
+
+			data, err := json.Marshal(gin.H{"refresh_token": refreshToken})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/users/renew", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder, refreshToken)
+		})
+	}
+}
+
+func TestLogoutUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	refreshToken, payload, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	_, accessPayload, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, rolePermissions(util.DepositorRole), time.Minute)
+	require.NoError(t, err)
+
+	session := db.Session{
+		ID:           payload.ID,
+		FamilyID:     payload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    payload.ExpiredAt,
+	}
+
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(payload.ID)).
+		Times(1).
+		Return(session, nil)
+
+	store.EXPECT().
+		BlockSessionFamily(gomock.Any(), gomock.Eq(payload.ID)).
+		Times(1).
+		Return(int64(1), nil)
+
+	store.EXPECT().
+		RevokeSessionTokens(gomock.Any(), gomock.Eq(session.ID)).
+		Times(1).
+		Return([]db.RevokedToken{{ID: accessPayload.ID, Username: user.Username, ExpiresAt: accessPayload.ExpiredAt}}, nil)
+
+	data, err := json.Marshal(gin.H{"refresh_token": refreshToken})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/users/logout", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+
+	// the access token issued with the session is rejected right away
+	revoked, err := server.revocations.IsRevoked(context.Background(), accessPayload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestRevokeSessionOfOtherUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	session := db.Session{
+		ID:        uuid.New(),
+		Username:  other.Username,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(session.ID)).
+		Times(1).
+		Return(session, nil)
+
+	store.EXPECT().
+		BlockSessionFamily(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodDelete, "/sessions/"+session.ID.String(), nil)
+	require.NoError(t, err)
+
+	AddAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestRevokeAllSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	_, accessPayload, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, rolePermissions(util.DepositorRole), time.Minute)
+	require.NoError(t, err)
+
+	store.EXPECT().
+		BlockUserSessions(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(int64(2), nil)
+
+	store.EXPECT().
+		SetTokenNotBefore(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SetTokenNotBeforeParams) (db.TokenCutoff, error) {
+			require.Equal(t, user.Username, arg.Username)
+			return db.TokenCutoff{Username: arg.Username, NotBefore: arg.NotBefore}, nil
+		})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodDelete, "/sessions", nil)
+	require.NoError(t, err)
+
+	AddAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// the tokens issued before, including the one of the request, are rejected right away
+	revoked, err := server.revocations.IsRevoked(context.Background(), accessPayload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
 )
 
 type renewAccessTokenRequest struct {
@@ -14,10 +17,15 @@ type renewAccessTokenRequest struct {
 }
 
 type renewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
+// renewAccessToken exchanges a refresh token for a new access token and a new refresh token,
+// the old refresh token cannot be used again
 func (server *Server) renewAccessToken(ctx *gin.Context) {
 	var req renewAccessTokenRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -31,50 +39,84 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	// the permissions are read again, so changes to the ones of the role apply from the next renewal
+	permissions, err := server.store.ListRolePermissions(ctx, refreshPayload.Role)
 	if err != nil {
-		if errors.Is(err, db.ErrorRecordNotFound) {
-			ctx.JSON(http.StatusUnauthorized, errorHandler(err))
-			return
-		}
-
 		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
-	if session.IsBlocked {
-		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
+	// the access token is created first, so the new session records it and revoking the session revokes it too
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		refreshPayload.Username,
+		refreshPayload.Role,
+		permissions,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
-	if session.Username != refreshPayload.Username {
-		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
-		return
-	}
+	var refreshToken string
+	var newRefreshPayload *token.Payload
+	result, err := server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		SessionID:    refreshPayload.ID,
+		Username:     refreshPayload.Username,
+		RefreshToken: req.RefreshToken,
+		ClientIp:     ctx.ClientIP(),
+		UserAgent:    ctx.Request.UserAgent(),
+		IssueRefreshToken: func(session db.Session) (db.CreateSessionParams, error) {
+			// the new token keeps the expiry of the login, so rotating cannot extend a session
+			var err error
+			refreshToken, newRefreshPayload, err = server.tokenMaker.CreateToken(
+				refreshPayload.Username,
+				refreshPayload.Role,
+				nil,
+				time.Until(session.ExpiresAt),
+			)
+			if err != nil {
+				return db.CreateSessionParams{}, err
+			}
 
-	if session.RefreshToken != req.RefreshToken {
-		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
-		return
-	}
-
-	if time.Now().After(refreshPayload.ExpiredAt) {
-		ctx.JSON(http.StatusUnauthorized, errorHandler(err))
-		return
+			return db.CreateSessionParams{
+				ID:           newRefreshPayload.ID,
+				RefreshToken: refreshToken,
+				ExpiresAt:    newRefreshPayload.ExpiredAt,
+				AccessTokenID: pgtype.UUID{
+					Bytes: accessPayload.ID,
+					Valid: true,
+				},
+			}, nil
+		},
+	})
+	if errors.Is(err, db.ErrRefreshTokenReused) {
+		// the family is blocked, the access tokens issued with it must not outlive it
+		if err := server.revocations.RevokeSessionTokens(ctx, refreshPayload.ID); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+			return
+		}
 	}
-
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
-		refreshPayload.Username,
-		refreshPayload.Role,
-		server.config.RefreshTokenDuration,
-	)
 	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) ||
+			errors.Is(err, db.ErrSessionBlocked) ||
+			errors.Is(err, db.ErrSessionExpired) ||
+			errors.Is(err, db.ErrSessionTokenMismatch) ||
+			errors.Is(err, db.ErrRefreshTokenReused) {
+			ctx.JSON(http.StatusUnauthorized, errorHandler(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
 	rsp := renewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		SessionID:             result.Session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: newRefreshPayload.ExpiredAt,
 	}
 
 	ctx.JSON(http.StatusOK, rsp)
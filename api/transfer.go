@@ -6,17 +6,26 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/money"
 	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/val"
 )
 
 type CreateTransferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount is in minor units of Currency, AmountDecimal in major units; exactly one must be set
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal" binding:"required_without=Amount,excluded_with=Amount"`
 	Currency      string `json:"currency" binding:"required,currency"`
+	// QuoteID is required when the to account holds a different currency
+	QuoteID string `json:"quote_id" binding:"omitempty,uuid"`
 }
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
 func (server *Server) createTransfer(ctx *gin.Context) {
 	var req CreateTransferRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -25,6 +34,24 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	idempotencyKey := ctx.GetHeader(idempotencyKeyHeader)
+	if err := val.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	if req.AmountDecimal != "" {
+		amount, err := money.Parse(req.AmountDecimal, req.Currency)
+		if err == nil {
+			err = val.ValidateAmount(amount.Amount)
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorHandler(err))
+			return
+		}
+		req.Amount = amount.Amount
+	}
+
 	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
@@ -37,26 +64,87 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
-	if !valid {
-		return
-	}
+	var transfer db.TransferTxResult
+	var err error
+	if req.QuoteID == "" {
+		_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+		if !valid {
+			return
+		}
 
-	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
-	}
+		arg := db.TransferTxParams{
+			FromAccountID:    req.FromAccountID,
+			ToAccountID:      req.ToAccountID,
+			Amount:           req.Amount,
+			Username:         authPayload.Username,
+			IdempotencyKey:   idempotencyKey,
+			ApprovalDuration: server.config.TransferApprovalDuration,
+		}
 
-	transfer, err := server.store.TransferTx(ctx, arg)
+		transfer, err = server.store.TransferTx(ctx, arg)
+	} else {
+		// the quote decides which currency the to account must hold
+		_, valid = server.validAccount(ctx, req.ToAccountID, "")
+		if !valid {
+			return
+		}
+
+		arg := db.ExchangeTransferTxParams{
+			FromAccountID:    req.FromAccountID,
+			ToAccountID:      req.ToAccountID,
+			Amount:           req.Amount,
+			QuoteID:          uuid.MustParse(req.QuoteID),
+			Username:         authPayload.Username,
+			IdempotencyKey:   idempotencyKey,
+			ApprovalDuration: server.config.TransferApprovalDuration,
+		}
+
+		transfer, err = server.store.ExchangeTransferTx(ctx, arg)
+	}
 	if err != nil {
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":     limitErr.Error(),
+				"limit":     limitErr.Limit,
+				"remaining": limitErr.Remaining,
+				"currency":  limitErr.Currency,
+			})
+			return
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountNotActive) ||
+			errors.Is(err, db.ErrExchangeQuoteInvalid) ||
+			errors.Is(err, db.ErrExchangeQuoteExpired) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrExchangeAmountInvalid) {
+			ctx.JSON(http.StatusBadRequest, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.JSON(http.StatusConflict, errorHandler(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
 		return
 	}
 
+	// nothing was transferred yet, a banker has to approve it first
+	if transfer.PendingApproval != nil {
+		ctx.JSON(http.StatusAccepted, transfer)
+		return
+	}
+
 	ctx.JSON(http.StatusCreated, transfer)
 }
 
+// validAccount checks that the account exists and, unless currency is empty, holds money in that currency
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
@@ -69,7 +157,7 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
-	if account.Currency != currency {
+	if currency != "" && account.Currency != currency {
 		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
 		ctx.JSON(http.StatusBadRequest, errorHandler(err))
 		return account, false
@@ -77,3 +165,64 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+type reverseTransferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	// Amount is returned to the original from account, in its currency; omit it to reverse whatever is left
+	Amount     int64  `json:"amount" binding:"omitempty,gt=0"`
+	ReasonCode string `json:"reason_code" binding:"required,reversal_reason"`
+	Note       string `json:"note" binding:"max=255"`
+}
+
+// reverseTransfer lets a banker undo all or part of a transfer with a compensating transfer
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayload).(*token.Payload)
+	var uri reverseTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorHandler(err))
+		return
+	}
+
+	arg := db.ReverseTransferTxParams{
+		TransferID: uri.ID,
+		Amount:     req.Amount,
+		ReasonCode: req.ReasonCode,
+		Note:       req.Note,
+		ApprovedBy: authPayload.Username,
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrTransferAlreadyReversed) || errors.Is(err, db.ErrTransferIsReversal) {
+			ctx.JSON(http.StatusConflict, errorHandler(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountNotActive) ||
+			errors.Is(err, db.ErrReversalAmountExceeded) ||
+			errors.Is(err, db.ErrExchangeAmountInvalid) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorHandler(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/leedrum/simplebank/db/sqlc"
 	"github.com/leedrum/simplebank/util"
 )
@@ -107,9 +108,16 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	permissions, err := server.store.ListRolePermissions(ctx, user.Role)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorHandler(err))
+		return
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
+		permissions,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -117,9 +125,11 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	// the refresh token grants no permission, so it cannot be used as an access token
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
+		nil,
 		server.config.RefreshTokenDuration,
 	)
 
@@ -130,11 +140,16 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
 		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		ExpiresAt:    refreshPayload.ExpiredAt,
 		ClientIp:     ctx.ClientIP(),
 		UserAgent:    ctx.Request.UserAgent(),
+		AccessTokenID: pgtype.UUID{
+			Bytes: accessPayload.ID,
+			Valid: true,
+		},
 	})
 
 	if err != nil {
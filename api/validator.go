@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
 )
 
 var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
@@ -11,3 +12,31 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validAdjustmentReason validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if reason, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsSupportedAdjustmentReason(reason)
+	}
+	return false
+}
+
+var validReversalReason validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if reason, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsSupportedReversalReason(reason)
+	}
+	return false
+}
+
+var validReference validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if reference, ok := fieldLevel.Field().Interface().(string); ok {
+		return val.ValidateReference(reference) == nil
+	}
+	return false
+}
+
+var validAccountStatus validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if status, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsSupportedAccountStatus(status)
+	}
+	return false
+}
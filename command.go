@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/ledger"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/rs/zerolog/log"
+)
+
+const commandUsage = `usage: simplebank [command]
+
+Without a command the servers are started.
+
+commands:
+  ledger verify      recompute balances from entries and report drift as JSON
+  keys list          list the token signing keys of TOKEN_KEYRING_FILE
+  keys add [id]      add a signing key and make it the active one
+  keys retire <id>   stop accepting tokens signed with a key
+`
+
+// runCommand runs a one-off admin command instead of the servers and returns the process exit code
+func runCommand(config util.Config, store db.Store, args []string) int {
+	switch {
+	case strings.Join(args, " ") == "ledger verify":
+		return runLedgerVerify(store)
+	case len(args) > 1 && args[0] == "keys":
+		return runKeys(config, args[1:])
+	default:
+		fmt.Fprint(os.Stderr, commandUsage)
+		return 2
+	}
+}
+
+// runLedgerVerify prints the ledger report to stdout and exits with 1 when drift is found
+func runLedgerVerify(store db.Store) int {
+	report, err := ledger.Verify(context.Background(), store)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot verify ledger")
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error().Err(err).Msg("cannot write ledger report")
+		return 1
+	}
+
+	if !report.Consistent {
+		return 1
+	}
+	return 0
+}
+
+// runKeys manages the keyring file. The servers read it when they start,
+// so they have to be restarted to sign with an added key or reject a retired one.
+func runKeys(config util.Config, args []string) int {
+	if config.TokenKeyringFile == "" {
+		log.Error().Msg("TOKEN_KEYRING_FILE is not set")
+		return 1
+	}
+
+	keyring, err := token.ReadKeyringFile(config.TokenKeyringFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		// start from the configured key, so tokens signed with it stay valid
+		keyring = token.NewKeyring(config.TokenSymmetricKey)
+		err = nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("cannot read keyring")
+		return 1
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tCREATED AT\tSTATUS")
+		for _, key := range keyring.Keys {
+			status := ""
+			switch {
+			case key.ID == keyring.ActiveKeyID:
+				status = "active"
+			case key.Retired():
+				status = "retired at " + key.RetiredAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", key.ID, key.CreatedAt.Format(time.RFC3339), status)
+		}
+		writer.Flush()
+		return 0
+	case args[0] == "add" && len(args) <= 2:
+		id := time.Now().UTC().Format("20060102150405")
+		if len(args) == 2 {
+			id = args[1]
+		}
+
+		if _, err := keyring.AddKey(id); err != nil {
+			log.Error().Err(err).Msg("cannot add key")
+			return 1
+		}
+	case args[0] == "retire" && len(args) == 2:
+		if err := keyring.RetireKey(args[1]); err != nil {
+			log.Error().Err(err).Str("id", args[1]).Msg("cannot retire key")
+			return 1
+		}
+	default:
+		fmt.Fprint(os.Stderr, commandUsage)
+		return 2
+	}
+
+	if err := keyring.WriteFile(config.TokenKeyringFile); err != nil {
+		log.Error().Err(err).Msg("cannot write keyring")
+		return 1
+	}
+
+	log.Info().Str("active_key_id", keyring.ActiveKeyID).Msg("keyring updated")
+	return 0
+}
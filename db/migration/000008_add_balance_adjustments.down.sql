@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "balance_adjustments";
+
+DROP TABLE IF EXISTS "system_accounts";
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'bank_suspense');
+
+DELETE FROM "accounts" WHERE "owner" = 'bank_suspense';
+
+DELETE FROM "users" WHERE "username" = 'bank_suspense';
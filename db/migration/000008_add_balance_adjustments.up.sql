@@ -0,0 +1,44 @@
+-- the bank owns its internal accounts through a dedicated user that cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('bank_suspense', '', 'Bank Suspense', 'suspense@simplebank.internal');
+
+CREATE TABLE "system_accounts" (
+  "purpose" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("purpose", "currency")
+);
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- suspense accounts absorb the other side of manual adjustments, so they may go far below zero
+INSERT INTO "accounts" ("owner", "balance", "currency", "overdraft_limit")
+SELECT 'bank_suspense', 0, currency, 1000000000000000
+FROM unnest(ARRAY['USD', 'EUR', 'CAD', 'VND']) AS currency;
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'suspense', "currency", "id" FROM "accounts" WHERE "owner" = 'bank_suspense';
+
+CREATE TABLE "balance_adjustments" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reason_code" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "entry_id" bigint NOT NULL,
+  "suspense_entry_id" bigint NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("suspense_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "balance_adjustments" ("account_id");
+
+COMMENT ON COLUMN "balance_adjustments"."amount" IS 'can be negative or positive';
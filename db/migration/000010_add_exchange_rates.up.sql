@@ -0,0 +1,48 @@
+CREATE TABLE "exchange_rates" (
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "rate" bigint NOT NULL,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("from_currency", "to_currency"),
+  CONSTRAINT "rate_positive" CHECK ("rate" > 0),
+  CONSTRAINT "currencies_differ" CHECK ("from_currency" <> "to_currency")
+);
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'to_currency amount for one from_currency amount, scaled by 10^8';
+
+CREATE TABLE "exchange_quotes" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "rate" bigint NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "exchange_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "exchange_quotes"."rate" IS 'scaled by 10^8, copied from exchange_rates when the quote was created';
+
+-- same-currency transfers keep to_amount = amount and a rate of exactly 1
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" bigint NOT NULL DEFAULT 100000000;
+
+ALTER TABLE "transfers" ADD COLUMN "quote_id" uuid;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("quote_id") REFERENCES "exchange_quotes" ("id");
+
+COMMENT ON COLUMN "transfers"."amount" IS 'must be positive, in the currency of from_account_id';
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'must be positive, in the currency of to_account_id';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'scaled by 10^8';
@@ -0,0 +1,34 @@
+-- rates go back to minor units of to_currency for one minor unit of from_currency
+CREATE TEMPORARY TABLE "currency_exponents" ("currency" varchar PRIMARY KEY, "exponent" int NOT NULL);
+
+-- currencies not listed have 2 decimal digits, as in the money package
+INSERT INTO "currency_exponents" VALUES
+  ('BHD', 3), ('CLP', 0), ('ISK', 0), ('JOD', 3), ('JPY', 0),
+  ('KRW', 0), ('KWD', 3), ('OMR', 3), ('TND', 3), ('VND', 0);
+
+UPDATE "exchange_rates" AS r
+SET "rate" = GREATEST(1, ROUND(r."rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."from_currency"), 2)
+)))::bigint;
+
+UPDATE "exchange_quotes" AS r
+SET "rate" = GREATEST(1, ROUND(r."rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."from_currency"), 2)
+)))::bigint;
+
+UPDATE "transfers" AS r
+SET "exchange_rate" = GREATEST(1, ROUND(r."exchange_rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."currency"), 2)
+)))::bigint
+WHERE r."currency" <> r."to_currency";
+
+DROP TABLE "currency_exponents";
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'to_currency amount for one from_currency amount, scaled by 10^8';
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "to_currency";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "currency";
@@ -0,0 +1,47 @@
+-- transfers carry their currencies so amounts can be formatted without loading both accounts
+ALTER TABLE "transfers" ADD COLUMN "currency" varchar;
+
+ALTER TABLE "transfers" ADD COLUMN "to_currency" varchar;
+
+UPDATE "transfers" AS t
+SET
+  "currency" = f."currency",
+  "to_currency" = a."currency"
+FROM "accounts" AS f, "accounts" AS a
+WHERE f."id" = t."from_account_id" AND a."id" = t."to_account_id";
+
+ALTER TABLE "transfers" ALTER COLUMN "currency" SET NOT NULL;
+
+ALTER TABLE "transfers" ALTER COLUMN "to_currency" SET NOT NULL;
+
+-- rates were minor units of to_currency for one minor unit of from_currency, they become per major unit
+-- of from_currency: a rate is multiplied by 10^(exponent of from_currency - exponent of to_currency)
+CREATE TEMPORARY TABLE "currency_exponents" ("currency" varchar PRIMARY KEY, "exponent" int NOT NULL);
+
+-- currencies not listed have 2 decimal digits, as in the money package
+INSERT INTO "currency_exponents" VALUES
+  ('BHD', 3), ('CLP', 0), ('ISK', 0), ('JOD', 3), ('JPY', 0),
+  ('KRW', 0), ('KWD', 3), ('OMR', 3), ('TND', 3), ('VND', 0);
+
+UPDATE "exchange_rates" AS r
+SET "rate" = GREATEST(1, ROUND(r."rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."from_currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2)
+)))::bigint;
+
+UPDATE "exchange_quotes" AS r
+SET "rate" = GREATEST(1, ROUND(r."rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."from_currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2)
+)))::bigint;
+
+UPDATE "transfers" AS r
+SET "exchange_rate" = GREATEST(1, ROUND(r."exchange_rate" * POWER(10::numeric,
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."currency"), 2) -
+  COALESCE((SELECT "exponent" FROM "currency_exponents" WHERE "currency" = r."to_currency"), 2)
+)))::bigint
+WHERE r."currency" <> r."to_currency";
+
+DROP TABLE "currency_exponents";
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'to_currency amount for one major unit of from_currency, scaled by 10^8';
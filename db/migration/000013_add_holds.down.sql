@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "holds";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "held_amount_non_negative";
+
+ALTER TABLE "accounts" DROP COLUMN "held_amount";
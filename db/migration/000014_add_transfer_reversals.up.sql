@@ -0,0 +1,29 @@
+CREATE TABLE "transfer_reversals" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "reversal_transfer_id" bigint UNIQUE NOT NULL,
+  "amount" bigint NOT NULL,
+  "to_amount" bigint NOT NULL,
+  "reason_code" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "approved_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_positive" CHECK ("amount" > 0),
+  CONSTRAINT "to_amount_positive" CHECK ("to_amount" > 0)
+);
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversal_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfer_reversals" ("transfer_id");
+
+COMMENT ON COLUMN "transfer_reversals"."transfer_id" IS 'the original transfer being reversed';
+
+COMMENT ON COLUMN "transfer_reversals"."reversal_transfer_id" IS 'the compensating transfer in the opposite direction';
+
+COMMENT ON COLUMN "transfer_reversals"."amount" IS 'returned to the original from account, in its currency';
+
+COMMENT ON COLUMN "transfer_reversals"."to_amount" IS 'taken back from the original to account, in its currency';
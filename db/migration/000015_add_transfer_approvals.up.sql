@@ -0,0 +1,50 @@
+CREATE TABLE "approval_thresholds" (
+  "currency" varchar PRIMARY KEY,
+  "amount" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_positive" CHECK ("amount" > 0)
+);
+
+COMMENT ON COLUMN "approval_thresholds"."amount" IS 'transfers above this amount, in minor units, wait for a banker approval';
+
+-- the equivalent of about 10,000 USD in every supported currency
+INSERT INTO "approval_thresholds" ("currency", "amount") VALUES
+  ('USD', 1000000),
+  ('EUR', 1000000),
+  ('CAD', 1400000),
+  ('VND', 250000000);
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending_approval',
+  "requested_by" varchar NOT NULL,
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_positive" CHECK ("amount" > 0),
+  CONSTRAINT "status_valid" CHECK ("status" IN ('pending_approval', 'approved', 'rejected', 'expired')),
+  CONSTRAINT "reviewer_is_not_requester" CHECK ("reviewed_by" <> "requested_by")
+);
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfer_approvals" ("expires_at") WHERE "status" = 'pending_approval';
+
+COMMENT ON COLUMN "transfer_approvals"."reviewed_by" IS 'the banker who approved or rejected, never the requester';
+
+COMMENT ON COLUMN "transfer_approvals"."transfer_id" IS 'set when the transfer was approved and executed';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
+
+DROP TABLE IF EXISTS "user_transfer_limits";
+
+DROP TABLE IF EXISTS "role_transfer_limits";
@@ -0,0 +1,44 @@
+-- outgoing transfer limits of every user with the role, unless overridden
+CREATE TABLE "role_transfer_limits" (
+  "role" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transaction" bigint NOT NULL,
+  "daily" bigint NOT NULL,
+  "monthly" bigint NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "currency"),
+  CONSTRAINT "limits_positive" CHECK ("per_transaction" > 0 AND "daily" > 0 AND "monthly" > 0)
+);
+
+-- about 25,000 USD a transfer, 50,000 a day and 200,000 a month for depositors, ten times that for bankers
+INSERT INTO "role_transfer_limits" ("role", "currency", "per_transaction", "daily", "monthly") VALUES
+  ('depositor', 'USD', 2500000, 5000000, 20000000),
+  ('depositor', 'EUR', 2500000, 5000000, 20000000),
+  ('depositor', 'CAD', 3500000, 7000000, 28000000),
+  ('depositor', 'VND', 625000000, 1250000000, 5000000000),
+  ('banker', 'USD', 25000000, 50000000, 200000000),
+  ('banker', 'EUR', 25000000, 50000000, 200000000),
+  ('banker', 'CAD', 35000000, 70000000, 280000000),
+  ('banker', 'VND', 6250000000, 12500000000, 50000000000);
+
+-- replaces the role limits of a user, a user has one account per currency so this also limits that account
+CREATE TABLE "user_transfer_limits" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transaction" bigint NOT NULL,
+  "daily" bigint NOT NULL,
+  "monthly" bigint NOT NULL,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency"),
+  CONSTRAINT "limits_positive" CHECK ("per_transaction" > 0 AND "daily" > 0 AND "monthly" > 0)
+);
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "user_transfer_limits"."updated_by" IS 'the banker who set the limits';
+
+-- speeds up summing the debits of an account over a day or a month
+CREATE INDEX ON "entries" ("account_id", "created_at") WHERE "amount" < 0;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "cash_operations";
+
+DELETE FROM "system_accounts" WHERE "purpose" IN ('cash', 'fees');
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" IN ('bank_cash', 'bank_fees'));
+
+DELETE FROM "accounts" WHERE "owner" IN ('bank_cash', 'bank_fees');
+
+DELETE FROM "users" WHERE "username" IN ('bank_cash', 'bank_fees');
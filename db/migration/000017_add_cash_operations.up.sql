@@ -0,0 +1,52 @@
+-- like bank_suspense, these users own internal accounts and cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('bank_cash', '', 'Bank Cash', 'cash@simplebank.internal'),
+  ('bank_fees', '', 'Bank Fees', 'fees@simplebank.internal');
+
+-- the cash account mirrors the money deposited at the bank, so it goes below zero as customers deposit
+INSERT INTO "accounts" ("owner", "balance", "currency", "overdraft_limit")
+SELECT 'bank_cash', 0, currency, 1000000000000000
+FROM unnest(ARRAY['USD', 'EUR', 'CAD', 'VND']) AS currency;
+
+-- the fees account collects revenue and can only pay out what it earned
+INSERT INTO "accounts" ("owner", "balance", "currency")
+SELECT 'bank_fees', 0, currency
+FROM unnest(ARRAY['USD', 'EUR', 'CAD', 'VND']) AS currency;
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'cash', "currency", "id" FROM "accounts" WHERE "owner" = 'bank_cash';
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'fees', "currency", "id" FROM "accounts" WHERE "owner" = 'bank_fees';
+
+CREATE TABLE "cash_operations" (
+  "id" bigserial PRIMARY KEY,
+  "type" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "system_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reference" varchar UNIQUE NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "entry_id" bigint NOT NULL,
+  "system_entry_id" bigint NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_positive" CHECK ("amount" > 0),
+  CONSTRAINT "type_valid" CHECK ("type" IN ('deposit', 'withdrawal'))
+);
+
+ALTER TABLE "cash_operations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cash_operations" ADD FOREIGN KEY ("system_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cash_operations" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "cash_operations" ADD FOREIGN KEY ("system_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "cash_operations" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "cash_operations" ("account_id");
+
+COMMENT ON COLUMN "cash_operations"."amount" IS 'always positive, the type tells the direction';
+
+COMMENT ON COLUMN "cash_operations"."reference" IS 'id of the operation in the external system, for reconciliation';
@@ -0,0 +1,51 @@
+-- transfers pay no fee until a banker adds a schedule for their type and currency
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "percent_bps" bigint NOT NULL DEFAULT 0,
+  "min_fee" bigint NOT NULL DEFAULT 0,
+  "max_fee" bigint NOT NULL DEFAULT 0,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_type_valid" CHECK ("transfer_type" IN ('transfer', 'exchange')),
+  CONSTRAINT "fees_non_negative" CHECK ("min_amount" >= 0 AND "flat_fee" >= 0 AND "percent_bps" >= 0 AND "min_fee" >= 0 AND "max_fee" >= 0),
+  CONSTRAINT "fee_schedules_tier_key" UNIQUE ("transfer_type", "currency", "min_amount")
+);
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "fee_schedules"."min_amount" IS 'the tier applies to transfers of at least this amount, up to the next tier';
+
+COMMENT ON COLUMN "fee_schedules"."percent_bps" IS 'percentage of the amount in basis points, 100 is 1%';
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS '0 means no cap';
+
+CREATE TABLE "transfer_fees" (
+  "transfer_id" bigint PRIMARY KEY,
+  "fee_schedule_id" bigint,
+  "flat_fee" bigint NOT NULL,
+  "percent_fee" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "revenue_entry_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_positive" CHECK ("amount" > 0)
+);
+
+ALTER TABLE "transfer_fees" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_fees" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "transfer_fees" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "transfer_fees" ADD FOREIGN KEY ("revenue_entry_id") REFERENCES "entries" ("id");
+
+COMMENT ON COLUMN "transfer_fees"."amount" IS 'charged to the from account on top of the transfer, after the min and max fee';
+
+COMMENT ON COLUMN "transfer_fees"."entry_id" IS 'debit of the from account, not linked to the transfer so the transfer keeps its two entries';
+
+COMMENT ON COLUMN "transfer_fees"."revenue_entry_id" IS 'credit of the bank fees account';
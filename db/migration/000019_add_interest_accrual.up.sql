@@ -0,0 +1,73 @@
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "interest_rate_bps" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_rate_bps_valid" CHECK ("interest_rate_bps" >= 0 AND "interest_rate_bps" <= 10000)
+);
+
+COMMENT ON COLUMN "account_products"."interest_rate_bps" IS 'yearly interest rate in basis points, 100 is 1%';
+
+INSERT INTO "account_products" ("code", "name", "interest_rate_bps") VALUES
+  ('checking', 'Checking', 0),
+  ('savings', 'Savings', 200);
+
+ALTER TABLE "accounts" ADD COLUMN "product" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+-- like bank_fees, this user owns internal accounts and cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('bank_interest', '', 'Bank Interest', 'interest@simplebank.internal');
+
+-- the interest expense account pays the interest earned by customers, so it goes below zero
+INSERT INTO "accounts" ("owner", "balance", "currency", "overdraft_limit")
+SELECT 'bank_interest', 0, currency, 1000000000000000
+FROM unnest(ARRAY['USD', 'EUR', 'CAD', 'VND']) AS currency;
+
+INSERT INTO "system_accounts" ("purpose", "currency", "account_id")
+SELECT 'interest', "currency", "id" FROM "accounts" WHERE "owner" = 'bank_interest';
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  "entry_id" bigint,
+  "expense_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "amount_not_negative" CHECK ("amount" >= 0)
+);
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("expense_entry_id") REFERENCES "entries" ("id");
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period");
+
+COMMENT ON COLUMN "interest_postings"."period" IS 'first day of the month the interest was earned in';
+
+COMMENT ON COLUMN "interest_postings"."entry_id" IS 'null when the interest rounded to zero';
+
+CREATE TABLE "interest_accruals" (
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "interest_rate_bps" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "posting_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "accrual_date")
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "posting_id" IS NULL;
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance at the end of the accrual date';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned on the day, in millionths of the minor unit';
@@ -0,0 +1,3 @@
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "consumed_at";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "family_id";
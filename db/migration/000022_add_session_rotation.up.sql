@@ -0,0 +1,13 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+ALTER TABLE "sessions" ADD COLUMN "consumed_at" timestamptz;
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the login session, shared by every refresh token rotated from it';
+
+COMMENT ON COLUMN "sessions"."consumed_at" IS 'set when the refresh token is exchanged, presenting it again revokes the whole family';
+
+CREATE INDEX ON "sessions" ("family_id");
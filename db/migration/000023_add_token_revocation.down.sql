@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "token_cutoffs";
+
+DROP TABLE IF EXISTS "revoked_tokens";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "access_token_id";
@@ -0,0 +1,26 @@
+ALTER TABLE "sessions" ADD COLUMN "access_token_id" uuid;
+
+COMMENT ON COLUMN "sessions"."access_token_id" IS 'id of the access token issued with the refresh token, revoked with the session';
+
+CREATE TABLE "revoked_tokens" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "revoked_tokens"."expires_at" IS 'the token is expired by then, so the row can be pruned';
+
+ALTER TABLE "revoked_tokens" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "revoked_tokens" ("expires_at");
+
+CREATE TABLE "token_cutoffs" (
+  "username" varchar PRIMARY KEY,
+  "not_before" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "token_cutoffs"."not_before" IS 'tokens of the user issued before are rejected';
+
+ALTER TABLE "token_cutoffs" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1,32 @@
+-- permissions granted to the access tokens of every user with the role
+CREATE TABLE "role_permissions" (
+  "role" varchar NOT NULL,
+  "permission" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
+-- depositors manage their own accounts, transfers and sessions, bankers also those of every other user
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'accounts:read'),
+  ('depositor', 'accounts:write'),
+  ('depositor', 'transfers:read'),
+  ('depositor', 'transfers:write'),
+  ('depositor', 'settings:read'),
+  ('depositor', 'sessions:read'),
+  ('depositor', 'sessions:write'),
+  ('depositor', 'users:write'),
+  ('depositor', 'tokens:write'),
+  ('banker', 'accounts:read'),
+  ('banker', 'accounts:write'),
+  ('banker', 'accounts:admin'),
+  ('banker', 'transfers:read'),
+  ('banker', 'transfers:write'),
+  ('banker', 'transfers:admin'),
+  ('banker', 'settings:read'),
+  ('banker', 'settings:admin'),
+  ('banker', 'sessions:read'),
+  ('banker', 'sessions:write'),
+  ('banker', 'users:write'),
+  ('banker', 'users:admin'),
+  ('banker', 'tokens:write');
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "scheduled_transfer_runs"."status" IS 'succeeded, retrying, skipped or failed';
+
+ALTER TABLE "scheduled_transfer_runs" DROP COLUMN IF EXISTS "approval_id";
+
+ALTER TABLE "transfer_approvals" DROP COLUMN IF EXISTS "quote_id";
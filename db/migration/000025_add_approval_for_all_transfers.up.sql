@@ -0,0 +1,13 @@
+ALTER TABLE "transfer_approvals" ADD COLUMN "quote_id" uuid;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("quote_id") REFERENCES "exchange_quotes" ("id");
+
+COMMENT ON COLUMN "transfer_approvals"."quote_id" IS 'set for a cross-currency transfer, executed at the rate of the quote once approved';
+
+ALTER TABLE "scheduled_transfer_runs" ADD COLUMN "approval_id" bigint;
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("approval_id") REFERENCES "transfer_approvals" ("id");
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."status" IS 'succeeded, pending_approval, retrying, skipped or failed';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."approval_id" IS 'set when the occurrence is above the approval threshold and waits for a banker';
@@ -0,0 +1,7 @@
+UPDATE "holds" SET "status" = 'active' WHERE "status" = 'pending_approval';
+
+ALTER TABLE "holds" DROP CONSTRAINT "status_valid";
+
+ALTER TABLE "holds" ADD CONSTRAINT "status_valid" CHECK ("status" IN ('active', 'captured', 'voided', 'expired'));
+
+ALTER TABLE "holds" DROP COLUMN IF EXISTS "approval_id";
@@ -0,0 +1,13 @@
+ALTER TABLE "holds" ADD COLUMN "approval_id" bigint;
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("approval_id") REFERENCES "transfer_approvals" ("id");
+
+ALTER TABLE "holds" DROP CONSTRAINT "status_valid";
+
+ALTER TABLE "holds" ADD CONSTRAINT "status_valid" CHECK ("status" IN ('active', 'pending_approval', 'captured', 'voided', 'expired'));
+
+CREATE INDEX ON "holds" ("approval_id");
+
+COMMENT ON COLUMN "holds"."approval_id" IS 'set when the capture is above the approval threshold and waits for a banker';
+
+COMMENT ON COLUMN "holds"."status" IS 'a pending_approval hold keeps its funds reserved until the capture is approved, rejected or expires';
@@ -0,0 +1,3 @@
+-- name: GetApprovalThreshold :one
+SELECT * FROM approval_thresholds
+WHERE currency = $1 LIMIT 1;
@@ -0,0 +1,25 @@
+-- name: CreateCashOperation :one
+INSERT INTO cash_operations (
+  type,
+  account_id,
+  system_account_id,
+  amount,
+  reference,
+  note,
+  entry_id,
+  system_entry_id,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetCashOperationByReference :one
+SELECT * FROM cash_operations
+WHERE reference = $1 LIMIT 1;
+
+-- name: ListCashOperations :many
+SELECT * FROM cash_operations
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
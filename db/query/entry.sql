@@ -1,9 +1,10 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -16,3 +17,13 @@ WHERE account_id = $1
 ORDER BY id
 LIMIT $2
 OFFSET $3;
+
+-- name: SumAccountDebits :one
+SELECT COALESCE(SUM(-amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1
+  AND amount < 0
+  AND transfer_id IS NOT NULL
+  AND created_at >= sqlc.arg(since)
+  AND NOT EXISTS (
+    SELECT 1 FROM transfer_reversals WHERE reversal_transfer_id = entries.transfer_id
+  );
@@ -0,0 +1,21 @@
+-- name: CreateExchangeQuote :one
+INSERT INTO exchange_quotes (
+  id,
+  username,
+  from_currency,
+  to_currency,
+  rate,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetExchangeQuoteForUpdate :one
+SELECT * FROM exchange_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: MarkExchangeQuoteUsed :exec
+UPDATE exchange_quotes
+SET used_at = now()
+WHERE id = $1;
@@ -0,0 +1,36 @@
+-- name: UpsertFeeSchedule :one
+INSERT INTO fee_schedules (
+  transfer_type,
+  currency,
+  min_amount,
+  flat_fee,
+  percent_bps,
+  min_fee,
+  max_fee,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (transfer_type, currency, min_amount) DO UPDATE
+SET
+  flat_fee = EXCLUDED.flat_fee,
+  percent_bps = EXCLUDED.percent_bps,
+  min_fee = EXCLUDED.min_fee,
+  max_fee = EXCLUDED.max_fee,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetFeeScheduleForAmount :one
+SELECT * FROM fee_schedules
+WHERE transfer_type = $1 AND currency = $2 AND min_amount <= sqlc.arg(amount)
+ORDER BY min_amount DESC
+LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY transfer_type, currency, min_amount;
+
+-- name: DeleteFeeSchedule :exec
+DELETE FROM fee_schedules
+WHERE id = $1;
@@ -0,0 +1,41 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  to_account_id,
+  amount,
+  created_by,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldByApprovalForUpdate :one
+SELECT * FROM holds
+WHERE approval_id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListExpiredHolds :many
+SELECT * FROM holds
+WHERE status = 'active' AND expires_at <= sqlc.arg(now)
+ORDER BY expires_at
+LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateHold :one
+UPDATE holds
+SET
+  status = $2,
+  captured_amount = $3,
+  transfer_id = $4,
+  approval_id = $5,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,63 @@
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  interest_rate_bps,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: GetLastInterestAccrualDate :one
+SELECT COALESCE(MAX(accrual_date), sqlc.arg(fallback)::date)::date AS accrual_date
+FROM interest_accruals;
+
+-- name: ListAccountsForInterestAccrual :many
+SELECT a.id FROM accounts AS a
+JOIN account_products AS p ON p.code = a.product
+WHERE p.interest_rate_bps > 0
+  AND a.status = 'active'
+  AND a.created_at < sqlc.arg(before)
+  AND a.id > sqlc.arg(after_id)
+ORDER BY a.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT i.account_id FROM interest_accruals AS i
+JOIN accounts AS a ON a.id = i.account_id
+WHERE i.posting_id IS NULL
+  AND a.status = 'active'
+  AND i.accrual_date < sqlc.arg(before)
+  AND i.account_id > sqlc.arg(after_id)
+ORDER BY i.account_id
+LIMIT sqlc.arg(limit_count);
+
+-- name: AssignInterestAccruals :many
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before)
+RETURNING amount;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  period
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (account_id, period) DO NOTHING
+RETURNING *;
+
+-- name: UpdateInterestPosting :one
+UPDATE interest_postings
+SET
+  amount = $2,
+  entry_id = $3,
+  expense_entry_id = $4
+WHERE id = $1
+RETURNING *;
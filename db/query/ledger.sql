@@ -0,0 +1,35 @@
+-- name: ListAccountBalanceDrift :many
+SELECT
+  a.id AS account_id,
+  a.owner,
+  a.currency,
+  a.balance,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_balance
+FROM accounts AS a
+LEFT JOIN entries AS e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListUnbalancedTransfers :many
+SELECT
+  t.id AS transfer_id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  t.to_amount,
+  COUNT(e.id) AS entry_count,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_sum
+FROM transfers AS t
+LEFT JOIN entries AS e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING COUNT(e.id) <> 2
+  OR COALESCE(SUM(e.amount), 0) <> t.to_amount - t.amount
+  OR COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id AND e.amount = -t.amount) <> 1
+  OR COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = t.to_amount) <> 1
+ORDER BY t.id;
+
+-- name: CountLedgerRows :one
+SELECT
+  (SELECT COUNT(*) FROM accounts) AS accounts,
+  (SELECT COUNT(*) FROM transfers) AS transfers;
@@ -0,0 +1,3 @@
+-- name: GetSystemAccount :one
+SELECT * FROM system_accounts
+WHERE purpose = $1 AND currency = $2 LIMIT 1;
@@ -0,0 +1,40 @@
+-- name: GetTokenRevocation :one
+SELECT
+  EXISTS (
+    SELECT 1 FROM revoked_tokens WHERE id = sqlc.arg(token_id)
+  )::bool AS revoked,
+  COALESCE(
+    (SELECT not_before FROM token_cutoffs WHERE username = sqlc.arg(username)),
+    'epoch'
+  )::timestamptz AS not_before;
+
+-- name: RevokeSessionTokens :many
+INSERT INTO revoked_tokens (
+  id,
+  username,
+  expires_at
+)
+SELECT access_token_id, username, expires_at FROM sessions
+WHERE family_id = (
+    SELECT family_id FROM sessions AS s WHERE s.id = sqlc.arg(session_id)
+  )
+  AND access_token_id IS NOT NULL
+ON CONFLICT (id) DO NOTHING
+RETURNING *;
+
+-- name: SetTokenNotBefore :one
+INSERT INTO token_cutoffs (
+  username,
+  not_before
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET
+  not_before = GREATEST(token_cutoffs.not_before, EXCLUDED.not_before),
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at < now();
@@ -0,0 +1,47 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  requested_by,
+  expires_at,
+  quote_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPendingTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE status = 'pending_approval'
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ReviewTransferApproval :one
+UPDATE transfer_approvals
+SET
+  status = $2,
+  reviewed_by = $3,
+  review_note = $4,
+  transfer_id = $5,
+  reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpireTransferApprovals :many
+UPDATE transfer_approvals
+SET
+  status = 'expired',
+  reviewed_at = now()
+WHERE status = 'pending_approval' AND expires_at <= sqlc.arg(now)
+RETURNING *;
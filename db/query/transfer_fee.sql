@@ -0,0 +1,17 @@
+-- name: CreateTransferFee :one
+INSERT INTO transfer_fees (
+  transfer_id,
+  fee_schedule_id,
+  flat_fee,
+  percent_fee,
+  amount,
+  currency,
+  entry_id,
+  revenue_entry_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetTransferFee :one
+SELECT * FROM transfer_fees
+WHERE transfer_id = $1 LIMIT 1;
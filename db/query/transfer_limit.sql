@@ -0,0 +1,27 @@
+-- name: GetRoleTransferLimit :one
+SELECT * FROM role_transfer_limits
+WHERE role = $1 AND currency = $2 LIMIT 1;
+
+-- name: GetUserTransferLimit :one
+SELECT * FROM user_transfer_limits
+WHERE username = $1 AND currency = $2 LIMIT 1;
+
+-- name: UpsertUserTransferLimit :one
+INSERT INTO user_transfer_limits (
+  username,
+  currency,
+  per_transaction,
+  daily,
+  monthly,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (username, currency) DO UPDATE
+SET
+  per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
@@ -0,0 +1,30 @@
+-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+  transfer_id,
+  reversal_transfer_id,
+  amount,
+  to_amount,
+  reason_code,
+  note,
+  approved_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetTransferReversalTotals :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS amount,
+  COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM transfer_reversals
+WHERE transfer_id = $1;
+
+-- name: IsReversalTransfer :one
+SELECT EXISTS (
+  SELECT 1 FROM transfer_reversals
+  WHERE reversal_transfer_id = $1
+) AS is_reversal;
+
+-- name: ListTransferReversals :many
+SELECT * FROM transfer_reversals
+WHERE transfer_id = $1
+ORDER BY id;
@@ -9,11 +9,16 @@ import (
 )
 
 func createRandomAccount(t *testing.T) Account {
+	return createRandomAccountWithBalance(t, util.RandomMoney())
+}
+
+func createRandomAccountWithBalance(t *testing.T, balance int64) Account {
 	user := createRandomUser(t)
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
+		Balance:  balance,
 		Currency: util.RandomCurrency(),
+		Product:  util.AccountProductChecking,
 	}
 
 	account, err := testStore.CreateAccount(context.Background(), arg)
@@ -23,6 +28,7 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.Product, account.Product)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -30,6 +36,19 @@ func createRandomAccount(t *testing.T) Account {
 	return account
 }
 
+func createAccountInCurrency(t *testing.T, balance int64, currency string) Account {
+	user := createRandomUser(t)
+	account, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+		Product:  util.AccountProductChecking,
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
 func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
@@ -47,33 +66,82 @@ func TestGetAccount(t *testing.T) {
 	require.Equal(t, account1.CreatedAt, account2.CreatedAt)
 }
 
-func TestUpdateAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-
-	arg := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: util.RandomMoney(),
+func TestChangeAccountStatusTx(t *testing.T) {
+	banker := createRandomUser(t)
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	require.Equal(t, util.AccountStatusActive, account1.Status)
+
+	changeStatus := func(accountID int64, status string) (ChangeAccountStatusTxResult, error) {
+		return testStore.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+			AccountID: accountID,
+			Status:    status,
+			Reason:    "test",
+			ChangedBy: banker.Username,
+		})
 	}
 
-	account2, err := testStore.UpdateAccount(context.Background(), arg)
+	// a frozen account can neither send nor receive money
+	result, err := changeStatus(account1.ID, util.AccountStatusFrozen)
+	require.NoError(t, err)
+	require.Equal(t, util.AccountStatusFrozen, result.Account.Status)
+	require.Equal(t, util.AccountStatusActive, result.Change.FromStatus)
+	require.Equal(t, util.AccountStatusFrozen, result.Change.ToStatus)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	_, err = changeStatus(account1.ID, util.AccountStatusFrozen)
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	_, err = changeStatus(account1.ID, util.AccountStatusActive)
 	require.NoError(t, err)
-	require.NotEmpty(t, account2)
 
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, arg.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, account1.CreatedAt, account2.CreatedAt)
-}
+	// only an empty account can be closed
+	_, err = changeStatus(account1.ID, util.AccountStatusClosed)
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
 
-func TestDeleteAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-	err := testStore.DeleteAccount(context.Background(), account1.ID)
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
 	require.NoError(t, err)
 
-	account2, err := testStore.GetAccount(context.Background(), account1.ID)
-	require.Error(t, err)
-	require.Empty(t, account2)
+	result, err = changeStatus(account1.ID, util.AccountStatusClosed)
+	require.NoError(t, err)
+	require.Equal(t, util.AccountStatusClosed, result.Account.Status)
+
+	_, err = changeStatus(account1.ID, util.AccountStatusFrozen)
+	require.ErrorIs(t, err, ErrAccountStatusTransition)
+
+	// the account and its history are kept, so it can be reopened
+	result, err = changeStatus(account1.ID, util.AccountStatusActive)
+	require.NoError(t, err)
+	require.Equal(t, util.AccountStatusActive, result.Account.Status)
+
+	changes, err := testStore.ListAccountStatusChanges(context.Background(), ListAccountStatusChangesParams{
+		AccountID: account1.ID,
+		Limit:     10,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	for _, change := range changes {
+		require.Equal(t, account1.ID, change.AccountID)
+		require.Equal(t, banker.Username, change.ChangedBy)
+	}
 }
 
 func TestListAccount(t *testing.T) {
@@ -9,9 +9,90 @@ import (
 
 var ErrorRecordNotFound = pgx.ErrNoRows
 
+// ErrInsufficientFunds is returned when a debit would take an account below its overdraft limit
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrIdempotencyKeyConflict is returned when an idempotency key is reused with a different request
+var ErrIdempotencyKeyConflict = errors.New("idempotency key was already used for a different request")
+
+// ErrExchangeQuoteInvalid is returned when an exchange quote is unknown, belongs to another user,
+// was already used, or does not match the currencies of the accounts
+var ErrExchangeQuoteInvalid = errors.New("invalid exchange quote")
+
+// ErrExchangeQuoteExpired is returned when an exchange quote is used after its rate lock ran out
+var ErrExchangeQuoteExpired = errors.New("exchange quote expired")
+
+// ErrExchangeAmountInvalid is returned when the converted amount rounds to zero or does not fit in an int64
+var ErrExchangeAmountInvalid = errors.New("invalid exchange amount")
+
+// ErrHoldNotActive is returned when a hold was already captured, voided or expired
+var ErrHoldNotActive = errors.New("hold is not active")
+
+// ErrHoldExpired is returned when a hold is captured after it expired but before the worker released it
+var ErrHoldExpired = errors.New("hold expired")
+
+// ErrHoldCaptureExceeded is returned when more than the held amount is captured
+var ErrHoldCaptureExceeded = errors.New("capture amount exceeds the held amount")
+
+// ErrTransferAlreadyReversed is returned when the whole amount of a transfer was already reversed
+var ErrTransferAlreadyReversed = errors.New("transfer was already reversed")
+
+// ErrReversalAmountExceeded is returned when a refund is larger than what is left of the transfer
+var ErrReversalAmountExceeded = errors.New("reversal amount exceeds the amount left to reverse")
+
+// ErrTransferIsReversal is returned when reversing a compensating transfer instead of the original one
+var ErrTransferIsReversal = errors.New("cannot reverse a reversal")
+
+// ErrTransferApprovalNotPending is returned when a transfer approval was already approved, rejected or expired
+var ErrTransferApprovalNotPending = errors.New("transfer approval is not pending")
+
+// ErrTransferApprovalExpired is returned when a transfer approval is reviewed after it expired
+// but before the worker closed it
+var ErrTransferApprovalExpired = errors.New("transfer approval expired")
+
+// ErrTransferLimitExceeded is matched by a TransferLimitError, which tells the remaining allowance
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// ErrReferenceAlreadyUsed is returned when a deposit or withdrawal reuses the reference of another operation
+var ErrReferenceAlreadyUsed = errors.New("reference was already used")
+
+// ErrInterestAlreadyAccrued is returned when interest was already accrued for an account on a day
+var ErrInterestAlreadyAccrued = errors.New("interest already accrued")
+
+// ErrInterestAlreadyPosted is returned when the interest of an account was already posted for a month
+var ErrInterestAlreadyPosted = errors.New("interest already posted")
+
+// ErrAccountNotActive is matched by an AccountNotActiveError, which tells the account and its status
+var ErrAccountNotActive = errors.New("account is not active")
+
+// ErrAccountStatusTransition is returned when an account cannot go from its status to the requested one
+var ErrAccountStatusTransition = errors.New("invalid account status transition")
+
+// ErrAccountBalanceNotZero is returned when closing an account that still holds or reserves money
+var ErrAccountBalanceNotZero = errors.New("account balance is not zero")
+
+// ErrSessionBlocked is returned when a refresh token of a revoked or logged out session is renewed
+var ErrSessionBlocked = errors.New("session is blocked")
+
+// ErrSessionExpired is returned when a refresh token is renewed after its session expired
+var ErrSessionExpired = errors.New("session expired")
+
+// ErrSessionTokenMismatch is returned when a refresh token does not match the session it claims
+var ErrSessionTokenMismatch = errors.New("mismatched session token")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already exchanged is presented again,
+// which revokes every session of its family
+var ErrRefreshTokenReused = errors.New("refresh token was already used")
+
+// ErrSelfReview is returned when a user tries to approve or reject a transfer they requested
+var ErrSelfReview = errors.New("cannot review your own transfer")
+
 const (
-	ForeignKeyViolation = "23503"
-	UniqueViolation     = "23505"
+	ForeignKeyViolation  = "23503"
+	UniqueViolation      = "23505"
+	CheckViolation       = "23514"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
 )
 
 func ErrorCode(err error) string {
@@ -22,3 +103,14 @@ func ErrorCode(err error) string {
 
 	return pgErr.Code
 }
+
+// IsRetryableError reports whether the transaction failed only because of concurrent transactions,
+// so running it again may succeed
+func IsRetryableError(err error) bool {
+	switch ErrorCode(err) {
+	case SerializationFailure, DeadlockDetected:
+		return true
+	}
+
+	return false
+}
@@ -3,11 +3,98 @@ package db
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultTxMaxAttempts = 3
+	txRetryBaseBackoff   = 10 * time.Millisecond
+	txRetryMaxBackoff    = 500 * time.Millisecond
 )
 
-// ExecTx executes a function within a database transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.connPool.Begin(ctx)
+type txConfig struct {
+	options     pgx.TxOptions
+	maxAttempts int
+}
+
+// TxOption configures how execTx runs a transaction
+type TxOption func(*txConfig)
+
+// WithIsolationLevel runs the transaction with the given isolation level instead of the database default
+func WithIsolationLevel(level pgx.TxIsoLevel) TxOption {
+	return func(config *txConfig) {
+		config.options.IsoLevel = level
+	}
+}
+
+// WithMaxAttempts bounds how many times a transaction is tried when it fails with a retryable error
+func WithMaxAttempts(attempts int) TxOption {
+	return func(config *txConfig) {
+		if attempts > 0 {
+			config.maxAttempts = attempts
+		}
+	}
+}
+
+// TxRetryError is returned when a transaction kept failing with retryable errors until it ran out of attempts
+type TxRetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *TxRetryError) Error() string {
+	return fmt.Sprintf("tx failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *TxRetryError) Unwrap() error {
+	return e.Err
+}
+
+// ExecTx executes a function within a database transaction.
+// Serialization failures and deadlocks roll the transaction back and run fn again after a jittered backoff,
+// so fn must not have side effects outside of the transaction that cannot be repeated.
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error, opts ...TxOption) error {
+	config := txConfig{
+		maxAttempts: defaultTxMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := store.runTx(ctx, config.options, fn)
+		if err == nil || !IsRetryableError(err) {
+			if err == nil && attempt > 1 {
+				log.Info().Int("attempts", attempt).Msg("tx succeeded after retry")
+			}
+			return err
+		}
+
+		if attempt >= config.maxAttempts {
+			return &TxRetryError{Attempts: attempt, Err: err}
+		}
+
+		backoff := txRetryBackoff(attempt)
+		log.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Dur("backoff", backoff).
+			Msg("retrying tx")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (store *SQLStore) runTx(ctx context.Context, options pgx.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.connPool.BeginTx(ctx, options)
 	if err != nil {
 		return err
 	}
@@ -16,10 +103,21 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	err = fn(q)
 	if err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
-			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
 		}
 		return err
 	}
 
 	return tx.Commit(ctx)
 }
+
+// txRetryBackoff doubles the wait on every attempt and picks a random point in it,
+// so transactions that collided do not collide again
+func txRetryBackoff(attempt int) time.Duration {
+	backoff := txRetryBaseBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > txRetryMaxBackoff {
+		backoff = txRetryMaxBackoff
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecTxRetry(t *testing.T) {
+	store := testStore.(*SQLStore)
+	serializationErr := &pgconn.PgError{Code: SerializationFailure}
+
+	attempts := 0
+	err := store.execTx(context.Background(), func(q *Queries) error {
+		attempts++
+		if attempts < 3 {
+			return serializationErr
+		}
+		return nil
+	}, WithIsolationLevel(pgx.Serializable), WithMaxAttempts(3))
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	// give up once the attempts are used
+	attempts = 0
+	err = store.execTx(context.Background(), func(q *Queries) error {
+		attempts++
+		return serializationErr
+	}, WithMaxAttempts(2))
+	require.Error(t, err)
+	require.Equal(t, 2, attempts)
+
+	var retryErr *TxRetryError
+	require.True(t, errors.As(err, &retryErr))
+	require.Equal(t, 2, retryErr.Attempts)
+	require.Equal(t, SerializationFailure, ErrorCode(err))
+
+	// other errors are not retried
+	attempts = 0
+	err = store.execTx(context.Background(), func(q *Queries) error {
+		attempts++
+		return ErrInsufficientFunds
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, 1, attempts)
+}
+
+func TestTxRetryBackoff(t *testing.T) {
+	for attempt := 1; attempt < 10; attempt++ {
+		backoff := txRetryBackoff(attempt)
+		require.Greater(t, backoff, time.Duration(0))
+		require.LessOrEqual(t, backoff, txRetryMaxBackoff)
+	}
+}
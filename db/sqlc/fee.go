@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BasisPoints is the scale of FeeSchedule.PercentBps, so 100 basis points are 1%
+const BasisPoints = 10000
+
+// FeeQuote is the breakdown of the fee a transfer would pay, in the currency of the from account
+type FeeQuote struct {
+	// ScheduleID is 0 when no fee schedule applies
+	ScheduleID int64  `json:"schedule_id"`
+	FlatFee    int64  `json:"flat_fee"`
+	PercentFee int64  `json:"percent_fee"`
+	Amount     int64  `json:"amount"`
+	Currency   string `json:"currency"`
+}
+
+// EstimateTransferFee returns the fee a transfer of amount from an account in currency would pay right now,
+// so clients can show it before the transfer is confirmed
+func (store *SQLStore) EstimateTransferFee(ctx context.Context, feeType string, currency string, amount int64) (FeeQuote, error) {
+	return quoteFee(ctx, store.Queries, feeType, currency, amount)
+}
+
+// computeFee applies a fee schedule to amount.
+// The percentage is rounded down, then the sum with the flat fee is raised to MinFee and capped at MaxFee.
+func computeFee(schedule FeeSchedule, amount int64) FeeQuote {
+	percentFee := new(big.Int).Mul(big.NewInt(amount), big.NewInt(schedule.PercentBps))
+	percentFee.Quo(percentFee, big.NewInt(BasisPoints))
+
+	quote := FeeQuote{
+		ScheduleID: schedule.ID,
+		FlatFee:    schedule.FlatFee,
+		PercentFee: percentFee.Int64(),
+		Currency:   schedule.Currency,
+	}
+
+	quote.Amount = quote.FlatFee + quote.PercentFee
+	if quote.Amount < schedule.MinFee {
+		quote.Amount = schedule.MinFee
+	}
+	if schedule.MaxFee > 0 && quote.Amount > schedule.MaxFee {
+		quote.Amount = schedule.MaxFee
+	}
+
+	return quote
+}
+
+// quoteFee finds the tier of the fee schedule that applies to amount, if any, and computes the fee
+func quoteFee(ctx context.Context, q *Queries, feeType string, currency string, amount int64) (FeeQuote, error) {
+	schedule, err := q.GetFeeScheduleForAmount(ctx, GetFeeScheduleForAmountParams{
+		TransferType: feeType,
+		Currency:     currency,
+		Amount:       amount,
+	})
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return FeeQuote{Currency: currency}, nil
+		}
+		return FeeQuote{}, err
+	}
+
+	return computeFee(schedule, amount), nil
+}
+
+// transferMoneyWithFee moves money like transferMoney and charges the fee of feeType on top of the amount,
+// crediting it to the bank fees account in the currency of the from account.
+// fromAccount must be locked, and is checked to cover both the amount and the fee.
+func transferMoneyWithFee(ctx context.Context, q *Queries, feeType string, fromAccount Account, arg CreateTransferParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	fee, err := quoteFee(ctx, q, feeType, fromAccount.Currency, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+
+	if err := checkSufficientFunds(fromAccount, arg.Amount+fee.Amount); err != nil {
+		return result, err
+	}
+
+	result, err = transferMoney(ctx, q, arg)
+	if err != nil || fee.Amount == 0 {
+		return result, err
+	}
+
+	posting, err := postAgainstSystemAccount(ctx, q, fromAccount.ID, SystemAccountFees, -fee.Amount)
+	if err != nil {
+		return result, err
+	}
+	result.FromAccount = posting.Account
+
+	transferFee, err := q.CreateTransferFee(ctx, CreateTransferFeeParams{
+		TransferID:     result.Transfer.ID,
+		FeeScheduleID:  pgtype.Int8{Int64: fee.ScheduleID, Valid: fee.ScheduleID != 0},
+		FlatFee:        fee.FlatFee,
+		PercentFee:     fee.PercentFee,
+		Amount:         fee.Amount,
+		Currency:       fee.Currency,
+		EntryID:        posting.Entry.ID,
+		RevenueEntryID: posting.SystemEntry.ID,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Fee = &transferFee
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// hashRequest returns a fingerprint of a request payload, used to detect a key being reused for a different request
+func hashRequest(parts ...any) (string, error) {
+	data, err := json.Marshal(parts)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey reserves the key for the user within the current transaction.
+// If the key was already used for the same request, the stored response is decoded into response and replayed is true.
+// A concurrent request holding the same key blocks the insert until it commits or rolls back,
+// so duplicates collapse to a single execution.
+func claimIdempotencyKey(
+	ctx context.Context,
+	q *Queries,
+	username string,
+	key string,
+	requestHash string,
+	response any,
+) (replayed bool, err error) {
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    username,
+		Key:         key,
+		RequestHash: requestHash,
+	})
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return false, err
+	}
+
+	existing, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: username,
+		Key:      key,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if existing.RequestHash != requestHash || len(existing.Response) == 0 {
+		return false, ErrIdempotencyKeyConflict
+	}
+
+	return true, json.Unmarshal(existing.Response, response)
+}
+
+// saveIdempotentResponse stores the response of a request that claimed the key, so retries can replay it
+func saveIdempotentResponse(ctx context.Context, q *Queries, username string, key string, response any) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+		Username: username,
+		Key:      key,
+		Response: data,
+	})
+	return err
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountBalanceDrift(t *testing.T) {
+	// created with a balance but no entries, so the whole balance is drift
+	drifted := createRandomAccountWithBalance(t, util.RandomInt(1, 1000))
+	balanced := createRandomAccountWithBalance(t, 0)
+
+	rows, err := testStore.ListAccountBalanceDrift(context.Background())
+	require.NoError(t, err)
+
+	found := map[int64]ListAccountBalanceDriftRow{}
+	for _, row := range rows {
+		found[row.AccountID] = row
+	}
+
+	require.Contains(t, found, drifted.ID)
+	require.Equal(t, drifted.Balance, found[drifted.ID].Balance)
+	require.Zero(t, found[drifted.ID].EntriesBalance)
+	require.NotContains(t, found, balanced.ID)
+}
+
+func TestListUnbalancedTransfers(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// a transfer row written without its entries
+	orphan, err := testStore.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Currency:      account1.Currency,
+		ToAmount:      10,
+		ToCurrency:    account2.Currency,
+		ExchangeRate:  ExchangeRateScale,
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+
+	found := map[int64]ListUnbalancedTransfersRow{}
+	for _, row := range rows {
+		found[row.TransferID] = row
+	}
+
+	require.NotContains(t, found, result.Transfer.ID)
+	require.Contains(t, found, orphan.ID)
+	require.Zero(t, found[orphan.ID].EntryCount)
+}
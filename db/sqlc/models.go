@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Account struct {
@@ -16,6 +17,67 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// sum of active holds, the available balance is balance minus held_amount
+	HeldAmount int64  `json:"held_amount"`
+	Product    string `json:"product"`
+	// frozen and closed accounts cannot send or receive money
+	Status string `json:"status"`
+}
+
+type AccountProduct struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// yearly interest rate in basis points, 100 is 1%
+	InterestRateBps int64     `json:"interest_rate_bps"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type AccountStatusChange struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ApprovalThreshold struct {
+	Currency string `json:"currency"`
+	// transfers above this amount, in minor units, wait for a banker approval
+	Amount    int64     `json:"amount"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BalanceAdjustment struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// can be negative or positive
+	Amount          int64     `json:"amount"`
+	ReasonCode      string    `json:"reason_code"`
+	Note            string    `json:"note"`
+	EntryID         int64     `json:"entry_id"`
+	SuspenseEntryID int64     `json:"suspense_entry_id"`
+	CreatedBy       string    `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type CashOperation struct {
+	ID              int64  `json:"id"`
+	Type            string `json:"type"`
+	AccountID       int64  `json:"account_id"`
+	SystemAccountID int64  `json:"system_account_id"`
+	// always positive, the type tells the direction
+	Amount int64 `json:"amount"`
+	// id of the operation in the external system, for reconciliation
+	Reference     string    `json:"reference"`
+	Note          string    `json:"note"`
+	EntryID       int64     `json:"entry_id"`
+	SystemEntryID int64     `json:"system_entry_id"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type Entry struct {
@@ -24,6 +86,152 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// set for the two entries written by a transfer
+	TransferID pgtype.Int8 `json:"transfer_id"`
+}
+
+type ExchangeQuote struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	// scaled by 10^8, copied from exchange_rates when the quote was created
+	Rate      int64              `json:"rate"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type ExchangeRate struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// to_currency amount for one major unit of from_currency, scaled by 10^8
+	Rate      int64     `json:"rate"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FeeSchedule struct {
+	ID           int64  `json:"id"`
+	TransferType string `json:"transfer_type"`
+	Currency     string `json:"currency"`
+	// the tier applies to transfers of at least this amount, up to the next tier
+	MinAmount int64 `json:"min_amount"`
+	FlatFee   int64 `json:"flat_fee"`
+	// percentage of the amount in basis points, 100 is 1%
+	PercentBps int64 `json:"percent_bps"`
+	MinFee     int64 `json:"min_fee"`
+	// 0 means no cap
+	MaxFee    int64     `json:"max_fee"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Hold struct {
+	ID int64 `json:"id"`
+	// account whose funds are held
+	AccountID int64 `json:"account_id"`
+	// account credited when the hold is captured
+	ToAccountID    int64 `json:"to_account_id"`
+	Amount         int64 `json:"amount"`
+	CapturedAmount int64 `json:"captured_amount"`
+	// a pending_approval hold keeps its funds reserved until the capture is approved, rejected or expires
+	Status string `json:"status"`
+	// set when the hold is captured
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	CreatedBy  string      `json:"created_by"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	// set when the capture is above the approval threshold and waits for a banker
+	ApprovalID pgtype.Int8 `json:"approval_id"`
+}
+
+type IdempotencyKey struct {
+	Username    string `json:"username"`
+	Key         string `json:"key"`
+	RequestHash string `json:"request_hash"`
+	// set when the request finished successfully
+	Response  []byte    `json:"response"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// balance at the end of the accrual date
+	Balance         int64 `json:"balance"`
+	InterestRateBps int64 `json:"interest_rate_bps"`
+	// interest earned on the day, in millionths of the minor unit
+	Amount    int64       `json:"amount"`
+	PostingID pgtype.Int8 `json:"posting_id"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was earned in
+	Period time.Time `json:"period"`
+	Amount int64     `json:"amount"`
+	// null when the interest rounded to zero
+	EntryID        pgtype.Int8 `json:"entry_id"`
+	ExpenseEntryID pgtype.Int8 `json:"expense_entry_id"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type RevokedToken struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	// the token is expired by then, so the row can be pruned
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RolePermission struct {
+	Role       string    `json:"role"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RoleTransferLimit struct {
+	Role           string    `json:"role"`
+	Currency       string    `json:"currency"`
+	PerTransaction int64     `json:"per_transaction"`
+	Daily          int64     `json:"daily"`
+	Monthly        int64     `json:"monthly"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	// cron expression or descriptor such as @monthly or @every 24h, evaluated in UTC
+	Schedule                string    `json:"schedule"`
+	InsufficientFundsPolicy string    `json:"insufficient_funds_policy"`
+	NextRunAt               time.Time `json:"next_run_at"`
+	// retries of the current occurrence after insufficient funds
+	RetryCount int32     `json:"retry_count"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64     `json:"id"`
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time `json:"scheduled_for"`
+	// succeeded, pending_approval, retrying, skipped or failed
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	Error      string      `json:"error"`
+	CreatedAt  time.Time   `json:"created_at"`
+	// set when the occurrence is above the approval threshold and waits for a banker
+	ApprovalID pgtype.Int8 `json:"approval_id"`
 }
 
 type Session struct {
@@ -35,15 +243,92 @@ type Session struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
+	// id of the login session, shared by every refresh token rotated from it
+	FamilyID uuid.UUID `json:"family_id"`
+	// set when the refresh token is exchanged, presenting it again revokes the whole family
+	ConsumedAt pgtype.Timestamptz `json:"consumed_at"`
+	// id of the access token issued with the refresh token, revoked with the session
+	AccessTokenID pgtype.UUID `json:"access_token_id"`
+}
+
+type SystemAccount struct {
+	Purpose   string `json:"purpose"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
+type TokenCutoff struct {
+	Username string `json:"username"`
+	// tokens of the user issued before are rejected
+	NotBefore time.Time `json:"not_before"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	// must be positive
+	// must be positive, in the currency of from_account_id
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// must be positive, in the currency of to_account_id
+	ToAmount int64 `json:"to_amount"`
+	// scaled by 10^8
+	ExchangeRate int64       `json:"exchange_rate"`
+	QuoteID      pgtype.UUID `json:"quote_id"`
+	Currency     string      `json:"currency"`
+	ToCurrency   string      `json:"to_currency"`
+}
+
+type TransferApproval struct {
+	ID            int64  `json:"id"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	RequestedBy   string `json:"requested_by"`
+	// the banker who approved or rejected, never the requester
+	ReviewedBy pgtype.Text `json:"reviewed_by"`
+	ReviewNote string      `json:"review_note"`
+	// set when the transfer was approved and executed
+	TransferID pgtype.Int8        `json:"transfer_id"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+	// set for a cross-currency transfer, executed at the rate of the quote once approved
+	QuoteID pgtype.UUID `json:"quote_id"`
+}
+
+type TransferFee struct {
+	TransferID    int64       `json:"transfer_id"`
+	FeeScheduleID pgtype.Int8 `json:"fee_schedule_id"`
+	FlatFee       int64       `json:"flat_fee"`
+	PercentFee    int64       `json:"percent_fee"`
+	// charged to the from account on top of the transfer, after the min and max fee
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// debit of the from account, not linked to the transfer so the transfer keeps its two entries
+	EntryID int64 `json:"entry_id"`
+	// credit of the bank fees account
+	RevenueEntryID int64     `json:"revenue_entry_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type TransferReversal struct {
+	ID int64 `json:"id"`
+	// the original transfer being reversed
+	TransferID int64 `json:"transfer_id"`
+	// the compensating transfer in the opposite direction
+	ReversalTransferID int64 `json:"reversal_transfer_id"`
+	// returned to the original from account, in its currency
+	Amount int64 `json:"amount"`
+	// taken back from the original to account, in its currency
+	ToAmount   int64     `json:"to_amount"`
+	ReasonCode string    `json:"reason_code"`
+	Note       string    `json:"note"`
+	ApprovedBy string    `json:"approved_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -58,6 +343,17 @@ type User struct {
 	Role              string    `json:"role"`
 }
 
+type UserTransferLimit struct {
+	Username       string `json:"username"`
+	Currency       string `json:"currency"`
+	PerTransaction int64  `json:"per_transaction"`
+	Daily          int64  `json:"daily"`
+	Monthly        int64  `json:"monthly"`
+	// the banker who set the limits
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type VerifyEmail struct {
 	ID         int64     `json:"id"`
 	Username   string    `json:"username"`
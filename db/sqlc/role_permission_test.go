@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRolePermissions(t *testing.T) {
+	depositor, err := testStore.ListRolePermissions(context.Background(), util.DepositorRole)
+	require.NoError(t, err)
+	require.Contains(t, depositor, "accounts:read")
+	require.NotContains(t, depositor, "accounts:admin")
+
+	banker, err := testStore.ListRolePermissions(context.Background(), util.BankerRole)
+	require.NoError(t, err)
+	require.Subset(t, banker, depositor)
+	require.Contains(t, banker, "accounts:admin")
+
+	unknown, err := testStore.ListRolePermissions(context.Background(), util.RandomString(6))
+	require.NoError(t, err)
+	require.Empty(t, unknown)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, username string, expiresAt time.Time) Session {
+	id := uuid.New()
+	arg := CreateSessionParams{
+		ID:           id,
+		FamilyID:     id,
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		ClientIp:     "127.0.0.1",
+		UserAgent:    "test",
+		ExpiresAt:    expiresAt,
+		AccessTokenID: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+	}
+
+	session, err := testStore.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.False(t, session.IsBlocked)
+
+	return session
+}
+
+func TestBlockSessions(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+	session2 := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+	createRandomSession(t, user.Username, time.Now().Add(-time.Hour))
+
+	arg := ListActiveSessionsParams{
+		Username: user.Username,
+		Limit:    10,
+		Offset:   0,
+	}
+
+	// expired sessions are not listed
+	sessions, err := testStore.ListActiveSessions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	blocked, err := testStore.BlockSessionFamily(context.Background(), session1.FamilyID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), blocked)
+
+	sessions, err = testStore.ListActiveSessions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session2.ID, sessions[0].ID)
+
+	revoked, err := testStore.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), revoked)
+
+	sessions, err = testStore.ListActiveSessions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+
+	rotate := func(session Session) (RotateSessionTxResult, error) {
+		return testStore.RotateSessionTx(context.Background(), RotateSessionTxParams{
+			SessionID:    session.ID,
+			Username:     session.Username,
+			RefreshToken: session.RefreshToken,
+			ClientIp:     "127.0.0.1",
+			UserAgent:    "test",
+			IssueRefreshToken: func(session Session) (CreateSessionParams, error) {
+				return CreateSessionParams{
+					ID:           uuid.New(),
+					RefreshToken: util.RandomString(32),
+					ExpiresAt:    session.ExpiresAt,
+				}, nil
+			},
+		})
+	}
+
+	result, err := rotate(session)
+	require.NoError(t, err)
+	rotated := result.Session
+	require.NotEqual(t, session.ID, rotated.ID)
+	require.Equal(t, session.FamilyID, rotated.FamilyID)
+	require.Equal(t, session.Username, rotated.Username)
+	require.False(t, rotated.ConsumedAt.Valid)
+
+	consumed, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, consumed.ConsumedAt.Valid)
+
+	// presenting the consumed token again revokes the whole family
+	_, err = rotate(session)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	rotated, err = testStore.GetSession(context.Background(), rotated.ID)
+	require.NoError(t, err)
+	require.True(t, rotated.IsBlocked)
+
+	_, err = rotate(rotated)
+	require.ErrorIs(t, err, ErrSessionBlocked)
+
+	// a token that does not match its session is rejected without consuming it
+	other := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+	other.RefreshToken = "other"
+	_, err = rotate(other)
+	require.ErrorIs(t, err, ErrSessionTokenMismatch)
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -9,8 +10,26 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (TransferTxResult, error)
+	ApproveTransferTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error)
+	RejectTransferTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error)
+	ExpireTransferApprovalsTx(ctx context.Context, now time.Time) ([]TransferApproval, error)
+	EstimateTransferFee(ctx context.Context, feeType string, currency string, amount int64) (FeeQuote, error)
+	GetTransferAllowance(ctx context.Context, account Account) (TransferAllowance, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (HoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (HoldTxResult, error)
+	RunScheduledTransferTx(ctx context.Context, arg RunScheduledTransferTxParams) (RunScheduledTransferTxResult, error)
+	DepositTx(ctx context.Context, arg CashOperationTxParams) (CashOperationTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashOperationTxParams) (CashOperationTxResult, error)
+	AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error)
+	ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error)
+	AccrueInterest(ctx context.Context, arg AccrueInterestParams) (InterestAccrual, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
 }
 
 // Store provides all functions to execute db queries and transaction
@@ -2,15 +2,21 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/leedrum/simplebank/money"
+	"github.com/leedrum/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createRandomAccountWithBalance(t, util.RandomInt(100, 1000))
+	account2 := createAccountInCurrency(t, util.RandomInt(100, 1000), account1.Currency)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	n := 5
@@ -49,6 +55,8 @@ func TestTransferTx(t *testing.T) {
 		require.Equal(t, account1.ID, transfer.FromAccountID)
 		require.Equal(t, account2.ID, transfer.ToAccountID)
 		require.Equal(t, amount, transfer.Amount)
+		require.Equal(t, account1.Currency, transfer.Currency)
+		require.Equal(t, account2.Currency, transfer.ToCurrency)
 		require.NotZero(t, transfer.ID)
 		require.NotZero(t, transfer.CreatedAt)
 
@@ -57,6 +65,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		require.NotZero(t, fromEntry.ID)
 		require.NotZero(t, fromEntry.CreatedAt)
 
@@ -64,6 +73,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
 
@@ -105,8 +115,8 @@ func TestTransferTx(t *testing.T) {
 }
 
 func TestTransferTxDeadLock(t *testing.T) {
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createRandomAccountWithBalance(t, util.RandomInt(100, 1000))
+	account2 := createAccountInCurrency(t, util.RandomInt(100, 1000), account1.Currency)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	n := 10
@@ -153,3 +163,1349 @@ func TestTransferTxDeadLock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 10)
+	account2 := createAccountInCurrency(t, util.RandomMoney(), account1.Currency)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	// nothing should have moved
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+
+	// the whole balance can still be spent
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.FromAccount.Balance)
+}
+
+func TestAddAccountBalanceCheckConstraint(t *testing.T) {
+	account := createRandomAccountWithBalance(t, 10)
+
+	_, err := testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: -11,
+	})
+	require.Error(t, err)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, util.RandomInt(100, 1000))
+	account2 := createAccountInCurrency(t, util.RandomMoney(), account1.Currency)
+
+	n := 5
+	arg := TransferTxParams{
+		FromAccountID:  account1.ID,
+		ToAccountID:    account2.ID,
+		Amount:         10,
+		Username:       account1.Owner,
+		IdempotencyKey: util.RandomString(16),
+	}
+
+	errs := make(chan error)
+	results := make(chan TransferTxResult)
+
+	// run n concurrent retries of the same request
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := testStore.TransferTx(context.Background(), arg)
+
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var transferID int64
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotZero(t, result.Transfer.ID)
+		if transferID == 0 {
+			transferID = result.Transfer.ID
+		}
+		require.Equal(t, transferID, result.Transfer.ID)
+	}
+
+	// the money moved only once
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-arg.Amount, updatedAccount1.Balance)
+
+	// the same key cannot be reused for another transfer
+	arg.Amount = 20
+	_, err = testStore.TransferTx(context.Background(), arg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrIdempotencyKeyConflict))
+}
+
+func TestAdjustBalanceTx(t *testing.T) {
+	account := createRandomAccountWithBalance(t, util.RandomInt(100, 1000))
+	banker := createRandomUser(t)
+	amount := int64(-30)
+
+	suspense, err := testStore.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountSuspense,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	suspenseAccount, err := testStore.GetAccount(context.Background(), suspense.AccountID)
+	require.NoError(t, err)
+
+	result, err := testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID:  account.ID,
+		Amount:     amount,
+		ReasonCode: util.AdjustmentChargeback,
+		Note:       "disputed payment",
+		CreatedBy:  banker.Username,
+	})
+	require.NoError(t, err)
+
+	// check audit record
+	adjustment := result.Adjustment
+	require.NotZero(t, adjustment.ID)
+	require.Equal(t, account.ID, adjustment.AccountID)
+	require.Equal(t, amount, adjustment.Amount)
+	require.Equal(t, util.AdjustmentChargeback, adjustment.ReasonCode)
+	require.Equal(t, banker.Username, adjustment.CreatedBy)
+	require.Equal(t, result.Entry.ID, adjustment.EntryID)
+	require.Equal(t, result.SuspenseEntry.ID, adjustment.SuspenseEntryID)
+
+	// check entries
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.Equal(t, suspense.AccountID, result.SuspenseEntry.AccountID)
+	require.Equal(t, -amount, result.SuspenseEntry.Amount)
+
+	// check balances
+	require.Equal(t, account.Balance+amount, result.Account.Balance)
+	require.GreaterOrEqual(t, result.SuspenseAccount.Balance-suspenseAccount.Balance, -amount)
+
+	// cannot take more than the account holds
+	_, err = testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID:  account.ID,
+		Amount:     -(result.Account.Balance + 1),
+		ReasonCode: util.AdjustmentChargeback,
+		CreatedBy:  banker.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func createTestExchangeQuote(t *testing.T, username string, rate int64, expiresAt time.Time) ExchangeQuote {
+	quote, err := testStore.CreateExchangeQuote(context.Background(), CreateExchangeQuoteParams{
+		ID:           uuid.New(),
+		Username:     username,
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         rate,
+		ExpiresAt:    expiresAt,
+	})
+	require.NoError(t, err)
+
+	return quote
+}
+
+func TestExchangeTransferTx(t *testing.T) {
+	account1 := createAccountInCurrency(t, 1000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+
+	// 1 USD = 0.9 EUR
+	rate := ExchangeRateScale * 9 / 10
+	quote := createTestExchangeQuote(t, account1.Owner, rate, time.Now().Add(time.Minute))
+
+	arg := ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	}
+
+	result, err := testStore.ExchangeTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(100), result.Transfer.Amount)
+	require.Equal(t, int64(90), result.Transfer.ToAmount)
+	require.Equal(t, rate, result.Transfer.ExchangeRate)
+	require.Equal(t, quote.ID, uuid.UUID(result.Transfer.QuoteID.Bytes))
+
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Equal(t, int64(90), result.ToEntry.Amount)
+	require.Equal(t, int64(900), result.FromAccount.Balance)
+	require.Equal(t, int64(90), result.ToAccount.Balance)
+
+	// a quote can only be used once
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExchangeQuoteInvalid)
+
+	// nor by anyone else
+	arg.QuoteID = createTestExchangeQuote(t, account1.Owner, rate, time.Now().Add(time.Minute)).ID
+	arg.Username = account2.Owner
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExchangeQuoteInvalid)
+
+	// nor after it expired
+	arg.QuoteID = createTestExchangeQuote(t, account1.Owner, rate, time.Now().Add(-time.Second)).ID
+	arg.Username = account1.Owner
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExchangeQuoteExpired)
+
+	// nor for accounts in other currencies
+	account3 := createAccountInCurrency(t, 0, util.CAD)
+	arg.QuoteID = createTestExchangeQuote(t, account1.Owner, rate, time.Now().Add(time.Minute)).ID
+	arg.ToAccountID = account3.ID
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExchangeQuoteInvalid)
+}
+
+func TestExchangeTransferTxApproval(t *testing.T) {
+	account1 := createAccountInCurrency(t, 3000000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+	banker := createRandomUser(t)
+
+	threshold, err := testStore.GetApprovalThreshold(context.Background(), util.USD)
+	require.NoError(t, err)
+
+	rate := ExchangeRateScale * 9 / 10
+	quote := createTestExchangeQuote(t, account1.Owner, rate, time.Now().Add(time.Minute))
+
+	arg := ExchangeTransferTxParams{
+		FromAccountID:    account1.ID,
+		ToAccountID:      account2.ID,
+		Amount:           threshold.Amount + 10,
+		QuoteID:          quote.ID,
+		Username:         account1.Owner,
+		ApprovalDuration: time.Hour,
+	}
+
+	result, err := testStore.ExchangeTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.PendingApproval)
+	require.Zero(t, result.Transfer.ID)
+
+	approval := *result.PendingApproval
+	require.Equal(t, TransferApprovalPending, approval.Status)
+	require.Equal(t, util.USD, approval.Currency)
+	require.Equal(t, arg.Amount, approval.Amount)
+	require.Equal(t, quote.ID, uuid.UUID(approval.QuoteID.Bytes))
+
+	// the quote is used up by the request
+	_, err = testStore.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExchangeQuoteInvalid)
+
+	fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, fromAccount.Balance)
+
+	reviewed, err := testStore.ApproveTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalApproved, reviewed.Approval.Status)
+
+	// executed at the rate of the quote
+	transfer := reviewed.Transfer.Transfer
+	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.Amount*9/10, transfer.ToAmount)
+	require.Equal(t, rate, transfer.ExchangeRate)
+	require.Equal(t, quote.ID, uuid.UUID(transfer.QuoteID.Bytes))
+	require.Equal(t, util.EUR, transfer.ToCurrency)
+	require.Equal(t, arg.Amount*9/10, reviewed.Transfer.ToAccount.Balance)
+}
+
+func TestConvertAmount(t *testing.T) {
+	converted, err := convertAmount(100, util.USD, util.EUR, ExchangeRateScale*9/10)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), converted)
+
+	// rounds down
+	converted, err = convertAmount(1, util.USD, util.EUR, ExchangeRateScale*3/2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), converted)
+
+	// one cent is worth 250 dong
+	converted, err = convertAmount(1, util.USD, util.VND, ExchangeRateScale*25000)
+	require.NoError(t, err)
+	require.Equal(t, int64(250), converted)
+
+	_, err = convertAmount(1, util.USD, util.EUR, ExchangeRateScale/2)
+	require.ErrorIs(t, err, ErrExchangeAmountInvalid)
+
+	_, err = convertAmount(math.MaxInt64, util.USD, util.EUR, ExchangeRateScale*2)
+	require.ErrorIs(t, err, ErrExchangeAmountInvalid)
+}
+
+func createTestScheduledTransfer(t *testing.T, fromAccount, toAccount Account, amount int64, policy string) ScheduledTransfer {
+	scheduled, err := testStore.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:                   fromAccount.Owner,
+		FromAccountID:           fromAccount.ID,
+		ToAccountID:             toAccount.ID,
+		Amount:                  amount,
+		Currency:                fromAccount.Currency,
+		Schedule:                "@daily",
+		InsufficientFundsPolicy: policy,
+		NextRunAt:               time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond),
+	})
+	require.NoError(t, err)
+
+	return scheduled
+}
+
+func TestRunScheduledTransferTx(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	scheduled := createTestScheduledTransfer(t, account1, account2, 60, util.InsufficientFundsRetry)
+
+	now := time.Now()
+	arg := RunScheduledTransferTxParams{
+		ID:           scheduled.ID,
+		ScheduledFor: scheduled.NextRunAt,
+		NextRunAt:    now.Add(24 * time.Hour),
+		RetryAt:      now.Add(time.Hour),
+		MaxRetries:   1,
+	}
+
+	result, err := testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledRunSucceeded, result.Run.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+	require.Equal(t, int64(40), result.Transfer.FromAccount.Balance)
+	require.WithinDuration(t, arg.NextRunAt, result.ScheduledTransfer.NextRunAt, time.Second)
+
+	// the same occurrence never runs twice
+	_, err = testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+
+	// the next occurrence cannot be covered, so it is retried once and then skipped
+	arg.ScheduledFor = result.ScheduledTransfer.NextRunAt
+	arg.NextRunAt = now.Add(48 * time.Hour)
+	arg.RetryAt = now.Add(25 * time.Hour)
+	result, err = testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledRunRetrying, result.Run.Status)
+	require.False(t, result.Run.TransferID.Valid)
+	require.Equal(t, int32(1), result.ScheduledTransfer.RetryCount)
+	require.WithinDuration(t, arg.RetryAt, result.ScheduledTransfer.NextRunAt, time.Second)
+
+	arg.ScheduledFor = result.ScheduledTransfer.NextRunAt
+	result, err = testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledRunSkipped, result.Run.Status)
+	require.Zero(t, result.ScheduledTransfer.RetryCount)
+	require.WithinDuration(t, arg.NextRunAt, result.ScheduledTransfer.NextRunAt, time.Second)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), updatedAccount1.Balance)
+
+	runs, err := testStore.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduled.ID,
+		Limit:               10,
+		Offset:              0,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	require.Equal(t, ScheduledRunSkipped, runs[0].Status)
+}
+
+func TestRunScheduledTransferTxApproval(t *testing.T) {
+	account1 := createAccountInCurrency(t, 3000000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+
+	threshold, err := testStore.GetApprovalThreshold(context.Background(), util.USD)
+	require.NoError(t, err)
+
+	scheduled := createTestScheduledTransfer(t, account1, account2, threshold.Amount+1, util.InsufficientFundsSkip)
+
+	now := time.Now()
+	arg := RunScheduledTransferTxParams{
+		ID:               scheduled.ID,
+		ScheduledFor:     scheduled.NextRunAt,
+		NextRunAt:        now.Add(24 * time.Hour),
+		RetryAt:          now.Add(time.Hour),
+		MaxRetries:       1,
+		ApprovalDuration: time.Hour,
+	}
+
+	result, err := testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledRunPendingApproval, result.Run.Status)
+	require.False(t, result.Run.TransferID.Valid)
+	require.NotNil(t, result.Transfer.PendingApproval)
+	require.Equal(t, result.Transfer.PendingApproval.ID, result.Run.ApprovalID.Int64)
+	require.WithinDuration(t, arg.NextRunAt, result.ScheduledTransfer.NextRunAt, time.Second)
+
+	approval := *result.Transfer.PendingApproval
+	require.Equal(t, TransferApprovalPending, approval.Status)
+	require.Equal(t, scheduled.Owner, approval.RequestedBy)
+	require.Equal(t, scheduled.Amount, approval.Amount)
+
+	// no money moved yet
+	fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, fromAccount.Balance)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+
+	holdResult, err := testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      70,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, holdResult.Hold.Status)
+	require.Equal(t, int64(100), holdResult.Account.Balance)
+	require.Equal(t, int64(70), holdResult.Account.HeldAmount)
+	require.Equal(t, int64(30), holdResult.Account.AvailableBalance())
+
+	// held funds cannot be spent twice
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        31,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 71,
+	})
+	require.ErrorIs(t, err, ErrHoldCaptureExceeded)
+
+	// a partial capture releases the rest of the hold
+	result, err := testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 50,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.Equal(t, int64(50), result.Hold.CapturedAmount)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, int64(50), result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldAmount)
+	require.Equal(t, int64(50), result.Transfer.ToAccount.Balance)
+
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 10,
+	})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestCaptureHoldTxLimits(t *testing.T) {
+	account1 := createAccountInCurrency(t, 1000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+	banker := createRandomUser(t)
+
+	_, err := testStore.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:       account1.Owner,
+		Currency:       util.USD,
+		PerTransaction: 100,
+		Daily:          150,
+		Monthly:        1000,
+		UpdatedBy:      banker.Username,
+	})
+	require.NoError(t, err)
+
+	// a hold above the per-transaction limit could never be captured
+	_, err = testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      101,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	holdResult, err := testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		Username:      account1.Owner,
+	})
+	require.NoError(t, err)
+
+	// a capture is a transfer, so it cannot go past the daily limit
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Amount: 100,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitDaily, limitErr.Limit)
+	require.Equal(t, int64(50), limitErr.Remaining)
+
+	// the hold is left as it was
+	hold, err := testStore.GetHold(context.Background(), holdResult.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, hold.Status)
+
+	fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), fromAccount.HeldAmount)
+}
+
+func TestCaptureHoldTxApproval(t *testing.T) {
+	threshold, err := testStore.GetApprovalThreshold(context.Background(), util.USD)
+	require.NoError(t, err)
+	amount := threshold.Amount + 1
+
+	// capturePending places a hold and captures it above the approval threshold
+	capturePending := func(t *testing.T) (Account, Account, CaptureHoldTxResult) {
+		account1 := createAccountInCurrency(t, 3000000, util.USD)
+		account2 := createAccountInCurrency(t, 0, util.USD)
+
+		holdResult, err := testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+			AccountID:   account1.ID,
+			ToAccountID: account2.ID,
+			Amount:      amount,
+			CreatedBy:   account1.Owner,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		result, err := testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+			HoldID:           holdResult.Hold.ID,
+			Amount:           amount,
+			ApprovalDuration: time.Hour,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.Transfer.PendingApproval)
+		require.Zero(t, result.Transfer.Transfer.ID)
+		require.Equal(t, HoldStatusPendingApproval, result.Hold.Status)
+		require.False(t, result.Hold.TransferID.Valid)
+		require.Equal(t, result.Transfer.PendingApproval.ID, result.Hold.ApprovalID.Int64)
+		require.Equal(t, account1.Owner, result.Transfer.PendingApproval.RequestedBy)
+
+		// no money moved yet, and the funds stay reserved
+		fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+		require.NoError(t, err)
+		require.Equal(t, account1.Balance, fromAccount.Balance)
+		require.Equal(t, amount, fromAccount.HeldAmount)
+
+		// the hold cannot be released while the capture waits
+		_, err = testStore.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+			HoldID: result.Hold.ID,
+			Status: HoldStatusVoided,
+		})
+		require.ErrorIs(t, err, ErrHoldNotActive)
+
+		return account1, account2, result
+	}
+
+	t.Run("Approved", func(t *testing.T) {
+		account1, account2, capture := capturePending(t)
+		banker := createRandomUser(t)
+
+		review, err := testStore.ApproveTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+			ID:         capture.Transfer.PendingApproval.ID,
+			ReviewedBy: banker.Username,
+		})
+		require.NoError(t, err)
+		require.Equal(t, amount, review.Transfer.Transfer.Amount)
+
+		hold, err := testStore.GetHold(context.Background(), capture.Hold.ID)
+		require.NoError(t, err)
+		require.Equal(t, HoldStatusCaptured, hold.Status)
+		require.Equal(t, amount, hold.CapturedAmount)
+		require.Equal(t, review.Transfer.Transfer.ID, hold.TransferID.Int64)
+
+		fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+		require.NoError(t, err)
+		require.Equal(t, account1.Balance-amount, fromAccount.Balance)
+		require.Zero(t, fromAccount.HeldAmount)
+
+		toAccount, err := testStore.GetAccount(context.Background(), account2.ID)
+		require.NoError(t, err)
+		require.Equal(t, amount, toAccount.Balance)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		account1, _, capture := capturePending(t)
+		banker := createRandomUser(t)
+
+		_, err := testStore.RejectTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+			ID:         capture.Transfer.PendingApproval.ID,
+			ReviewedBy: banker.Username,
+		})
+		require.NoError(t, err)
+
+		hold, err := testStore.GetHold(context.Background(), capture.Hold.ID)
+		require.NoError(t, err)
+		require.Equal(t, HoldStatusVoided, hold.Status)
+		require.Zero(t, hold.CapturedAmount)
+		require.False(t, hold.TransferID.Valid)
+
+		fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+		require.NoError(t, err)
+		require.Equal(t, account1.Balance, fromAccount.Balance)
+		require.Zero(t, fromAccount.HeldAmount)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		account1, _, capture := capturePending(t)
+
+		_, err := testStore.ExpireTransferApprovalsTx(context.Background(), capture.Transfer.PendingApproval.ExpiresAt)
+		require.NoError(t, err)
+
+		hold, err := testStore.GetHold(context.Background(), capture.Hold.ID)
+		require.NoError(t, err)
+		require.Equal(t, HoldStatusExpired, hold.Status)
+
+		fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+		require.NoError(t, err)
+		require.Zero(t, fromAccount.HeldAmount)
+	})
+}
+
+func TestReleaseHoldTx(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+
+	holdResult, err := testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      1,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// a hold cannot be expired early
+	_, err = testStore.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Status: HoldStatusExpired,
+	})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	result, err := testStore.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		HoldID: holdResult.Hold.ID,
+		Status: HoldStatusVoided,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusVoided, result.Hold.Status)
+	require.Zero(t, result.Hold.CapturedAmount)
+	require.Equal(t, int64(100), result.Account.Balance)
+	require.Zero(t, result.Account.HeldAmount)
+
+	// expired holds are released by the worker and cannot be captured
+	expiredResult, err := testStore.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      10,
+		CreatedBy:   account1.Owner,
+		ExpiresAt:   time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: expiredResult.Hold.ID,
+		Amount: 10,
+	})
+	require.ErrorIs(t, err, ErrHoldExpired)
+
+	result, err = testStore.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		HoldID: expiredResult.Hold.ID,
+		Status: HoldStatusExpired,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusExpired, result.Hold.Status)
+	require.Zero(t, result.Account.HeldAmount)
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createAccountInCurrency(t, 0, account1.Currency)
+	banker := createRandomUser(t)
+
+	transfer, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	require.NoError(t, err)
+
+	// partial refund
+	result, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     20,
+		ReasonCode: util.ReversalWrongAmount,
+		ApprovedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.ID, result.Reversal.TransferID)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Reversal.ReversalTransferID)
+	require.Equal(t, int64(20), result.Reversal.Amount)
+	require.Equal(t, account2.ID, result.Transfer.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.Transfer.ToAccountID)
+	require.Equal(t, int64(60), result.Transfer.ToAccount.Balance)
+	require.Equal(t, int64(40), result.Transfer.FromAccount.Balance)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     41,
+		ReasonCode: util.ReversalWrongAmount,
+		ApprovedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrReversalAmountExceeded)
+
+	// a reversal cannot itself be reversed
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.Transfer.ID,
+		ReasonCode: util.ReversalDuplicate,
+		ApprovedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferIsReversal)
+
+	// the rest of the transfer
+	result, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReasonCode: util.ReversalDuplicate,
+		ApprovedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), result.Reversal.Amount)
+	require.Equal(t, int64(100), result.Transfer.ToAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReasonCode: util.ReversalDuplicate,
+		ApprovedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+
+	reversals, err := testStore.ListTransferReversals(context.Background(), transfer.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, reversals, 2)
+}
+
+func TestReverseTransferTxAllowance(t *testing.T) {
+	account1 := createAccountInCurrency(t, 100, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+	banker := createRandomUser(t)
+
+	_, err := testStore.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:       account2.Owner,
+		Currency:       util.USD,
+		PerTransaction: 100,
+		Daily:          100,
+		Monthly:        1000,
+		UpdatedBy:      banker.Username,
+	})
+	require.NoError(t, err)
+
+	transfer, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		ReasonCode: util.ReversalDuplicate,
+		ApprovedBy: banker.Username,
+	})
+	require.NoError(t, err)
+
+	// the recipient did not send the reversal, so it does not count toward their limits
+	allowance, err := testStore.GetTransferAllowance(context.Background(), account2)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), allowance.DailyRemaining)
+	require.Equal(t, int64(1000), allowance.MonthlyRemaining)
+}
+
+func TestReversalToAmount(t *testing.T) {
+	// 100 USD cents became 90 EUR cents
+	original := Transfer{Amount: 100, ToAmount: 90}
+
+	toAmount := reversalToAmount(original, GetTransferReversalTotalsRow{}, 33)
+	require.Equal(t, int64(29), toAmount)
+
+	toAmount = reversalToAmount(original, GetTransferReversalTotalsRow{Amount: 33, ToAmount: 29}, 33)
+	require.Equal(t, int64(30), toAmount)
+
+	// the last refund takes back exactly what is left
+	toAmount = reversalToAmount(original, GetTransferReversalTotalsRow{Amount: 66, ToAmount: 59}, 34)
+	require.Equal(t, int64(31), toAmount)
+
+	require.Equal(t, int64(ExchangeRateScale*2), inverseExchangeRate(ExchangeRateScale/2))
+	require.Equal(t, int64(1), inverseExchangeRate(math.MaxInt64))
+}
+
+func TestTransferTxApproval(t *testing.T) {
+	account1 := createAccountInCurrency(t, 3000000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+	banker := createRandomUser(t)
+
+	threshold, err := testStore.GetApprovalThreshold(context.Background(), util.USD)
+	require.NoError(t, err)
+
+	// up to the threshold the transfer is executed right away
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:    account1.ID,
+		ToAccountID:      account2.ID,
+		Amount:           threshold.Amount,
+		Username:         account1.Owner,
+		ApprovalDuration: time.Hour,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.PendingApproval)
+	require.NotZero(t, result.Transfer.ID)
+
+	result, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:    account1.ID,
+		ToAccountID:      account2.ID,
+		Amount:           threshold.Amount + 1,
+		Username:         account1.Owner,
+		ApprovalDuration: time.Hour,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.PendingApproval)
+	require.Zero(t, result.Transfer.ID)
+
+	approval := *result.PendingApproval
+	require.Equal(t, TransferApprovalPending, approval.Status)
+	require.Equal(t, util.USD, approval.Currency)
+	require.Equal(t, account1.Owner, approval.RequestedBy)
+	require.WithinDuration(t, time.Now().Add(time.Hour), approval.ExpiresAt, time.Minute)
+
+	// no money moved yet
+	fromAccount, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-threshold.Amount, fromAccount.Balance)
+
+	_, err = testStore.ApproveTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrSelfReview)
+
+	reviewed, err := testStore.ApproveTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+		Note:       "customer called in",
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalApproved, reviewed.Approval.Status)
+	require.Equal(t, banker.Username, reviewed.Approval.ReviewedBy.String)
+	require.Equal(t, reviewed.Transfer.Transfer.ID, reviewed.Approval.TransferID.Int64)
+	require.True(t, reviewed.Approval.ReviewedAt.Valid)
+	require.Equal(t, approval.Amount, reviewed.Transfer.Transfer.Amount)
+	require.Equal(t, account1.Balance-threshold.Amount-approval.Amount, reviewed.Transfer.FromAccount.Balance)
+
+	_, err = testStore.RejectTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferApprovalNotPending)
+}
+
+func TestRejectTransferTx(t *testing.T) {
+	account1 := createAccountInCurrency(t, 0, util.EUR)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+	banker := createRandomUser(t)
+
+	approval, err := testStore.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5000000,
+		Currency:      util.EUR,
+		RequestedBy:   account1.Owner,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	errNotify := errors.New("cannot notify")
+	_, err = testStore.RejectTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+		AfterReview: func(approval TransferApproval) error {
+			return errNotify
+		},
+	})
+	require.ErrorIs(t, err, errNotify)
+
+	// the failed callback rolled the rejection back
+	approval, err = testStore.GetTransferApproval(context.Background(), approval.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalPending, approval.Status)
+
+	result, err := testStore.RejectTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+		Note:       "unusual amount",
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalRejected, result.Approval.Status)
+	require.Equal(t, "unusual amount", result.Approval.ReviewNote)
+	require.False(t, result.Approval.TransferID.Valid)
+	require.Zero(t, result.Transfer.Transfer.ID)
+}
+
+func TestExpireTransferApprovals(t *testing.T) {
+	account1 := createAccountInCurrency(t, 0, util.CAD)
+	account2 := createAccountInCurrency(t, 0, util.CAD)
+	banker := createRandomUser(t)
+
+	approval, err := testStore.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5000000,
+		Currency:      util.CAD,
+		RequestedBy:   account1.Owner,
+		ExpiresAt:     time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	// the worker has not closed it yet
+	_, err = testStore.ApproveTransferTx(context.Background(), ReviewTransferApprovalTxParams{
+		ID:         approval.ID,
+		ReviewedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferApprovalExpired)
+
+	expired, err := testStore.ExpireTransferApprovals(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	var found bool
+	for _, e := range expired {
+		if e.ID == approval.ID {
+			found = true
+			require.Equal(t, TransferApprovalExpired, e.Status)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	account1 := createAccountInCurrency(t, 1000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+	banker := createRandomUser(t)
+
+	_, err := testStore.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:       account1.Owner,
+		Currency:       util.USD,
+		PerTransaction: 100,
+		Daily:          150,
+		Monthly:        1000,
+		UpdatedBy:      banker.Username,
+	})
+	require.NoError(t, err)
+
+	transfer := func(amount int64) error {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+			Username:      account1.Owner,
+		})
+		return err
+	}
+
+	err = transfer(101)
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+	require.Equal(t, TransferLimitPerTransaction, limitErr.Limit)
+	require.Equal(t, int64(100), limitErr.Remaining)
+
+	require.NoError(t, transfer(100))
+
+	err = transfer(60)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, account1.ID, limitErr.AccountID)
+	require.Equal(t, TransferLimitDaily, limitErr.Limit)
+	require.Equal(t, int64(50), limitErr.Remaining)
+
+	require.NoError(t, transfer(50))
+
+	allowance, err := testStore.GetTransferAllowance(context.Background(), account1)
+	require.NoError(t, err)
+	require.True(t, allowance.Limits.Overridden)
+	require.Zero(t, allowance.DailyRemaining)
+	require.Equal(t, int64(850), allowance.MonthlyRemaining)
+	require.Zero(t, allowance.Remaining())
+}
+
+func TestCashOperationTx(t *testing.T) {
+	account := createRandomAccountWithBalance(t, 0)
+	banker := createRandomUser(t)
+	reference := "test:" + util.RandomString(12)
+
+	deposit, err := testStore.DepositTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    100,
+		Reference: reference,
+		CreatedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CashOperationDeposit, deposit.Operation.Type)
+	require.Equal(t, int64(100), deposit.Account.Balance)
+	require.Equal(t, int64(100), deposit.Entry.Amount)
+	require.Equal(t, int64(-100), deposit.SystemEntry.Amount)
+	require.Equal(t, deposit.SystemAccount.ID, deposit.Operation.SystemAccountID)
+	require.Equal(t, account.Currency, deposit.SystemAccount.Currency)
+
+	cash, err := testStore.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountCash,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cash.AccountID, deposit.SystemAccount.ID)
+
+	// the reference identifies one operation only
+	_, err = testStore.WithdrawTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+		Reference: reference,
+		CreatedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrReferenceAlreadyUsed)
+
+	_, err = testStore.WithdrawTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    101,
+		Reference: reference + ":w",
+		CreatedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	withdrawal, err := testStore.WithdrawTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    40,
+		Reference: reference + ":w",
+		CreatedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CashOperationWithdrawal, withdrawal.Operation.Type)
+	require.Equal(t, int64(40), withdrawal.Operation.Amount)
+	require.Equal(t, int64(60), withdrawal.Account.Balance)
+	require.Equal(t, deposit.SystemAccount.Balance+40, withdrawal.SystemAccount.Balance)
+
+	operation, err := testStore.GetCashOperationByReference(context.Background(), reference)
+	require.NoError(t, err)
+	require.Equal(t, deposit.Operation.ID, operation.ID)
+}
+
+func TestComputeFee(t *testing.T) {
+	schedule := FeeSchedule{
+		ID:         1,
+		Currency:   util.USD,
+		FlatFee:    25,
+		PercentBps: 150,
+		MinFee:     50,
+		MaxFee:     1000,
+	}
+
+	quote := computeFee(schedule, 10000)
+	require.Equal(t, int64(1), quote.ScheduleID)
+	require.Equal(t, int64(25), quote.FlatFee)
+	require.Equal(t, int64(150), quote.PercentFee)
+	require.Equal(t, int64(175), quote.Amount)
+	require.Equal(t, util.USD, quote.Currency)
+
+	// the percentage rounds down, then the fee is raised to the minimum
+	quote = computeFee(schedule, 99)
+	require.Equal(t, int64(1), quote.PercentFee)
+	require.Equal(t, int64(50), quote.Amount)
+
+	quote = computeFee(schedule, 1000000)
+	require.Equal(t, int64(15000), quote.PercentFee)
+	require.Equal(t, int64(1000), quote.Amount)
+
+	// 0 means there is no cap
+	schedule.MaxFee = 0
+	quote = computeFee(schedule, math.MaxInt64)
+	require.Equal(t, schedule.FlatFee+quote.PercentFee, quote.Amount)
+}
+
+func TestTransferTxFee(t *testing.T) {
+	account1 := createAccountInCurrency(t, 600000, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.USD)
+	banker := createRandomUser(t)
+
+	schedule, err := testStore.UpsertFeeSchedule(context.Background(), UpsertFeeScheduleParams{
+		TransferType: util.FeeTypeTransfer,
+		Currency:     util.USD,
+		MinAmount:    500000,
+		FlatFee:      100,
+		PercentBps:   50,
+		MaxFee:       10000,
+		UpdatedBy:    banker.Username,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testStore.DeleteFeeSchedule(context.Background(), schedule.ID))
+	})
+
+	quote, err := testStore.EstimateTransferFee(context.Background(), util.FeeTypeTransfer, util.USD, 500000)
+	require.NoError(t, err)
+	require.Equal(t, schedule.ID, quote.ScheduleID)
+	require.Equal(t, int64(2600), quote.Amount)
+
+	// the account must cover the fee on top of the amount
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        600000,
+		Username:      account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	fees, err := testStore.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Purpose:  SystemAccountFees,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	feesBefore, err := testStore.GetAccount(context.Background(), fees.AccountID)
+	require.NoError(t, err)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500000,
+		Username:      account1.Owner,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Fee)
+	require.Equal(t, result.Transfer.ID, result.Fee.TransferID)
+	require.Equal(t, int64(100), result.Fee.FlatFee)
+	require.Equal(t, int64(2500), result.Fee.PercentFee)
+	require.Equal(t, int64(2600), result.Fee.Amount)
+	require.Equal(t, int64(600000-500000-2600), result.FromAccount.Balance)
+	require.Equal(t, int64(500000), result.ToAccount.Balance)
+
+	feesAfter, err := testStore.GetAccount(context.Background(), fees.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, feesBefore.Balance+2600, feesAfter.Balance)
+
+	// amounts below the lowest tier are free
+	result, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		Username:      account1.Owner,
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Fee)
+}
+
+func TestComputeDailyInterest(t *testing.T) {
+	date := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// 2% of 10000.00 USD over 365 days, in millionths of a cent
+	interest, err := computeDailyInterest(1000000, 200, date)
+	require.NoError(t, err)
+	require.Equal(t, int64(54794520), interest)
+
+	leapYear := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	interest, err = computeDailyInterest(1000000, 200, leapYear)
+	require.NoError(t, err)
+	require.Equal(t, int64(54644808), interest)
+
+	interest, err = computeDailyInterest(-1000, 200, date)
+	require.NoError(t, err)
+	require.Zero(t, interest)
+
+	_, err = computeDailyInterest(math.MaxInt64, BasisPoints, date)
+	require.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestRoundInterest(t *testing.T) {
+	require.Equal(t, int64(0), roundInterest(InterestScale/2))
+	require.Equal(t, int64(2), roundInterest(InterestScale*3/2))
+	require.Equal(t, int64(1), roundInterest(InterestScale/2+1))
+	require.Equal(t, int64(1), roundInterest(InterestScale*3/2-1))
+	require.Equal(t, int64(54), roundInterest(54494520))
+}
+
+func TestInterestAccrualAndPosting(t *testing.T) {
+	user := createRandomUser(t)
+	account, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  0,
+		Currency: util.USD,
+		Product:  util.AccountProductSavings,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DepositTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    1000000,
+		Reference: "test:" + util.RandomString(12),
+		CreatedBy: user.Username,
+	})
+	require.NoError(t, err)
+
+	product, err := testStore.GetAccountProduct(context.Background(), util.AccountProductSavings)
+	require.NoError(t, err)
+
+	today := time.Now().UTC()
+	accrual, err := testStore.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID: account.ID,
+		Date:      today,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000000), accrual.Balance)
+	require.Equal(t, product.InterestRateBps, accrual.InterestRateBps)
+	require.Equal(t, today.Format(time.DateOnly), accrual.AccrualDate.Format(time.DateOnly))
+
+	expected, err := computeDailyInterest(1000000, product.InterestRateBps, today)
+	require.NoError(t, err)
+	require.Equal(t, expected, accrual.Amount)
+
+	// a rerun of the same day accrues nothing
+	_, err = testStore.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID: account.ID,
+		Date:      today,
+	})
+	require.ErrorIs(t, err, ErrInterestAlreadyAccrued)
+
+	// the day before the deposit the account was empty
+	yesterday, err := testStore.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID: account.ID,
+		Date:      today.AddDate(0, 0, -1),
+	})
+	require.NoError(t, err)
+	require.Zero(t, yesterday.Balance)
+	require.Zero(t, yesterday.Amount)
+
+	result, err := testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    today,
+	})
+	require.NoError(t, err)
+	require.Equal(t, roundInterest(expected), result.Posting.Amount)
+	require.Equal(t, 1000000+result.Posting.Amount, result.Account.Balance)
+	if result.Posting.Amount > 0 {
+		require.NotNil(t, result.Entry)
+		require.Equal(t, result.Posting.Amount, result.Entry.Amount)
+		require.Equal(t, -result.Posting.Amount, result.ExpenseEntry.Amount)
+	}
+
+	_, err = testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    today,
+	})
+	require.ErrorIs(t, err, ErrInterestAlreadyPosted)
+}
+
+func TestInterestSkipsFrozenAccounts(t *testing.T) {
+	user := createRandomUser(t)
+	account, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  0,
+		Currency: util.USD,
+		Product:  util.AccountProductSavings,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DepositTx(context.Background(), CashOperationTxParams{
+		AccountID: account.ID,
+		Amount:    1000000,
+		Reference: "test:" + util.RandomString(12),
+		CreatedBy: user.Username,
+	})
+	require.NoError(t, err)
+
+	today := time.Now().UTC()
+	_, err = testStore.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID: account.ID,
+		Date:      today,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    util.AccountStatusFrozen,
+		ChangedBy: user.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID: account.ID,
+		Date:      today.AddDate(0, 0, -1),
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	accrualIDs, err := testStore.ListAccountsForInterestAccrual(context.Background(), ListAccountsForInterestAccrualParams{
+		Before:     today.AddDate(0, 0, 1),
+		AfterID:    account.ID - 1,
+		LimitCount: 1,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, accrualIDs, account.ID)
+
+	postingIDs, err := testStore.ListAccountsWithUnpostedInterest(context.Background(), ListAccountsWithUnpostedInterestParams{
+		Before:     today.AddDate(0, 0, 1),
+		AfterID:    account.ID - 1,
+		LimitCount: 1,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, postingIDs, account.ID)
+
+	_, err = testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    today,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// the accrued interest is posted once the account is active again
+	_, err = testStore.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    util.AccountStatusActive,
+		ChangedBy: user.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    today,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeSessionTokens(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+	accessTokenID := uuid.UUID(session.AccessTokenID.Bytes)
+
+	arg := GetTokenRevocationParams{
+		TokenID:  accessTokenID,
+		Username: user.Username,
+	}
+
+	revocation, err := testStore.GetTokenRevocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, revocation.Revoked)
+
+	tokens, err := testStore.RevokeSessionTokens(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, accessTokenID, tokens[0].ID)
+	require.Equal(t, user.Username, tokens[0].Username)
+
+	// revoking again is a no-op
+	tokens, err = testStore.RevokeSessionTokens(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	revocation, err = testStore.GetTokenRevocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, revocation.Revoked)
+}
+
+func TestSetTokenNotBefore(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := GetTokenRevocationParams{
+		TokenID:  uuid.New(),
+		Username: user.Username,
+	}
+
+	revocation, err := testStore.GetTokenRevocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, revocation.Revoked)
+	require.True(t, revocation.NotBefore.Before(time.Now().Add(-time.Hour)))
+
+	notBefore := time.Now()
+	cutoff, err := testStore.SetTokenNotBefore(context.Background(), SetTokenNotBeforeParams{
+		Username:  user.Username,
+		NotBefore: notBefore,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, notBefore, cutoff.NotBefore, time.Millisecond)
+
+	// the cutoff never moves back
+	cutoff, err = testStore.SetTokenNotBefore(context.Background(), SetTokenNotBeforeParams{
+		Username:  user.Username,
+		NotBefore: notBefore.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, notBefore, cutoff.NotBefore, time.Millisecond)
+
+	revocation, err = testStore.GetTokenRevocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.WithinDuration(t, notBefore, revocation.NotBefore, time.Millisecond)
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Constants for the limit a transfer ran into
+const (
+	TransferLimitPerTransaction = "per_transaction"
+	TransferLimitDaily          = "daily"
+	TransferLimitMonthly        = "monthly"
+)
+
+// TransferLimits are the outgoing transfer limits of an account, in minor units of its currency
+type TransferLimits struct {
+	PerTransaction int64 `json:"per_transaction"`
+	Daily          int64 `json:"daily"`
+	Monthly        int64 `json:"monthly"`
+	// Overridden is true when a banker set limits for the user instead of the defaults of their role
+	Overridden bool `json:"overridden"`
+}
+
+// TransferAllowance is how much an account can still send today and this month
+type TransferAllowance struct {
+	Limits           TransferLimits `json:"limits"`
+	DailyRemaining   int64          `json:"daily_remaining"`
+	MonthlyRemaining int64          `json:"monthly_remaining"`
+}
+
+// Remaining is the largest amount a single transfer can send right now
+func (allowance TransferAllowance) Remaining() int64 {
+	return min(allowance.Limits.PerTransaction, allowance.DailyRemaining, allowance.MonthlyRemaining)
+}
+
+// TransferLimitError is returned when a transfer would exceed a limit of the from account.
+// It matches ErrTransferLimitExceeded with errors.Is.
+type TransferLimitError struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// Limit is the period of the limit that was exceeded, one of the TransferLimit constants
+	Limit string `json:"limit"`
+	// Remaining is the largest amount the account can still send
+	Remaining int64 `json:"remaining"`
+}
+
+func (err *TransferLimitError) Error() string {
+	return fmt.Sprintf("%s: account [%d] exceeds its %s limit, %d %s remaining",
+		ErrTransferLimitExceeded, err.AccountID, err.Limit, err.Remaining, err.Currency)
+}
+
+func (err *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
+
+// GetTransferAllowance returns the limits of the account and how much it can still send.
+// It returns ErrorRecordNotFound when no limits apply to the account.
+func (store *SQLStore) GetTransferAllowance(ctx context.Context, account Account) (TransferAllowance, error) {
+	return getTransferAllowance(ctx, store.Queries, account, time.Now())
+}
+
+// getTransferLimits returns the limits a banker set for the user, or else the defaults of the user's role
+func getTransferLimits(ctx context.Context, q *Queries, username string, currency string) (TransferLimits, error) {
+	override, err := q.GetUserTransferLimit(ctx, GetUserTransferLimitParams{
+		Username: username,
+		Currency: currency,
+	})
+	if err == nil {
+		return TransferLimits{
+			PerTransaction: override.PerTransaction,
+			Daily:          override.Daily,
+			Monthly:        override.Monthly,
+			Overridden:     true,
+		}, nil
+	}
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return TransferLimits{}, err
+	}
+
+	user, err := q.GetUser(ctx, username)
+	if err != nil {
+		return TransferLimits{}, err
+	}
+
+	defaults, err := q.GetRoleTransferLimit(ctx, GetRoleTransferLimitParams{
+		Role:     user.Role,
+		Currency: currency,
+	})
+	if err != nil {
+		return TransferLimits{}, err
+	}
+
+	return TransferLimits{
+		PerTransaction: defaults.PerTransaction,
+		Daily:          defaults.Daily,
+		Monthly:        defaults.Monthly,
+	}, nil
+}
+
+// getTransferAllowance sums the outgoing transfers of the account since the start of the day and of the month, in UTC
+func getTransferAllowance(ctx context.Context, q *Queries, account Account, now time.Time) (TransferAllowance, error) {
+	var allowance TransferAllowance
+
+	limits, err := getTransferLimits(ctx, q, account.Owner, account.Currency)
+	if err != nil {
+		return allowance, err
+	}
+	allowance.Limits = limits
+
+	now = now.UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	sentToday, err := q.SumAccountDebits(ctx, SumAccountDebitsParams{
+		AccountID: account.ID,
+		Since:     startOfDay,
+	})
+	if err != nil {
+		return allowance, err
+	}
+
+	sentThisMonth, err := q.SumAccountDebits(ctx, SumAccountDebitsParams{
+		AccountID: account.ID,
+		Since:     startOfMonth,
+	})
+	if err != nil {
+		return allowance, err
+	}
+
+	allowance.DailyRemaining = max(limits.Daily-sentToday, 0)
+	allowance.MonthlyRemaining = max(limits.Monthly-sentThisMonth, 0)
+	return allowance, nil
+}
+
+// checkTransferLimits returns a TransferLimitError if the account cannot send amount.
+// The account must be locked, so that concurrent transfers cannot both fit in the same allowance.
+// An account without limits for its currency can send any amount.
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64) error {
+	allowance, err := getTransferAllowance(ctx, q, account, time.Now())
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	limit := ""
+	switch {
+	case amount > allowance.Limits.PerTransaction:
+		limit = TransferLimitPerTransaction
+	case amount > allowance.DailyRemaining:
+		limit = TransferLimitDaily
+	case amount > allowance.MonthlyRemaining:
+		limit = TransferLimitMonthly
+	default:
+		return nil
+	}
+
+	return &TransferLimitError{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Limit:     limit,
+		Remaining: allowance.Remaining(),
+	}
+}
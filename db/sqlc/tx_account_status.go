@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/leedrum/simplebank/util"
+)
+
+// accountStatusTransitions lists the statuses an account can move to from each status
+var accountStatusTransitions = map[string][]string{
+	util.AccountStatusActive: {util.AccountStatusFrozen, util.AccountStatusClosed},
+	util.AccountStatusFrozen: {util.AccountStatusActive, util.AccountStatusClosed},
+	util.AccountStatusClosed: {util.AccountStatusActive},
+}
+
+// ChangeAccountStatusTxParams contains the input parameters of the account status transaction
+type ChangeAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	ChangedBy string `json:"changed_by"`
+	// FromStatus, when set, is the only status the account may be changed from
+	FromStatus string `json:"from_status"`
+}
+
+// ChangeAccountStatusTxResult is the result of the account status transaction
+type ChangeAccountStatusTxResult struct {
+	Account Account             `json:"account"`
+	Change  AccountStatusChange `json:"change"`
+}
+
+// ChangeAccountStatusTx freezes, unfreezes, closes or reopens an account and records the change.
+// It returns ErrAccountStatusTransition if the account cannot move to the status,
+// and ErrAccountBalanceNotZero when closing an account with a balance or active holds.
+// An AccountNotActiveError is returned when the account is not in arg.FromStatus.
+func (store *SQLStore) ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error) {
+	var result ChangeAccountStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.FromStatus != "" && account.Status != arg.FromStatus {
+			return &AccountNotActiveError{AccountID: account.ID, Status: account.Status}
+		}
+
+		if !slices.Contains(accountStatusTransitions[account.Status], arg.Status) {
+			return fmt.Errorf("%w: account [%d] is %s and cannot become %s",
+				ErrAccountStatusTransition, account.ID, account.Status, arg.Status)
+		}
+
+		if arg.Status == util.AccountStatusClosed && (account.Balance != 0 || account.HeldAmount != 0) {
+			return fmt.Errorf("%w: account [%d] has balance %d and %d held",
+				ErrAccountBalanceNotZero, account.ID, account.Balance, account.HeldAmount)
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			Status: arg.Status,
+			ID:     account.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Change, err = q.CreateAccountStatusChange(ctx, CreateAccountStatusChangeParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   arg.Status,
+			Reason:     arg.Reason,
+			ChangedBy:  arg.ChangedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// AccountNotActiveError is returned when money would move in or out of a frozen or closed account.
+// It matches ErrAccountNotActive with errors.Is.
+type AccountNotActiveError struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+}
+
+func (err *AccountNotActiveError) Error() string {
+	return fmt.Sprintf("%s: account [%d] is %s", ErrAccountNotActive, err.AccountID, err.Status)
+}
+
+func (err *AccountNotActiveError) Unwrap() error {
+	return ErrAccountNotActive
+}
+
+// checkAccountsActive returns an AccountNotActiveError for the first account that is frozen or closed.
+// The accounts must be locked, so their status cannot change before the money moves.
+func checkAccountsActive(accounts ...Account) error {
+	for _, account := range accounts {
+		if account.Status != util.AccountStatusActive {
+			return &AccountNotActiveError{AccountID: account.ID, Status: account.Status}
+		}
+	}
+
+	return nil
+}
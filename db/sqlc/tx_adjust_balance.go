@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+)
+
+// SystemAccountSuspense is the purpose of the bank accounts that take the other side of manual adjustments
+const SystemAccountSuspense = "suspense"
+
+// AdjustBalanceTxParams contains the input parameters of the balance adjustment transaction
+type AdjustBalanceTxParams struct {
+	AccountID  int64  `json:"account_id"`
+	Amount     int64  `json:"amount"`
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+	CreatedBy  string `json:"created_by"`
+}
+
+// AdjustBalanceTxResult is the result of the balance adjustment transaction
+type AdjustBalanceTxResult struct {
+	Adjustment      BalanceAdjustment `json:"adjustment"`
+	Account         Account           `json:"account"`
+	SuspenseAccount Account           `json:"suspense_account"`
+	Entry           Entry             `json:"entry"`
+	SuspenseEntry   Entry             `json:"suspense_entry"`
+}
+
+// AdjustBalanceTx corrects an account's balance by a signed amount.
+// The opposite amount is posted to the bank's suspense account of the same currency,
+// so every balance keeps matching the sum of its entries, and the adjustment is recorded for audit.
+func (store *SQLStore) AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error) {
+	var result AdjustBalanceTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		posting, err := postAgainstSystemAccount(ctx, q, arg.AccountID, SystemAccountSuspense, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		// the account is locked by now, the posting is rolled back if it was frozen or closed
+		if err := checkAccountsActive(posting.Account); err != nil {
+			return err
+		}
+
+		result.Account = posting.Account
+		result.SuspenseAccount = posting.SystemAccount
+		result.Entry = posting.Entry
+		result.SuspenseEntry = posting.SystemEntry
+
+		result.Adjustment, err = q.CreateBalanceAdjustment(ctx, CreateBalanceAdjustmentParams{
+			AccountID:       posting.Account.ID,
+			Amount:          arg.Amount,
+			ReasonCode:      arg.ReasonCode,
+			Note:            arg.Note,
+			EntryID:         result.Entry.ID,
+			SuspenseEntryID: result.SuspenseEntry.ID,
+			CreatedBy:       arg.CreatedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Constants for the purpose of the bank's internal accounts, one per currency
+const (
+	// SystemAccountCash mirrors the money deposited at or withdrawn from the bank
+	SystemAccountCash = "cash"
+	// SystemAccountFees collects the fees charged to customers
+	SystemAccountFees = "fees"
+	// SystemAccountInterest pays the interest earned by customers
+	SystemAccountInterest = "interest"
+)
+
+// Constants for the type of a cash operation
+const (
+	CashOperationDeposit    = "deposit"
+	CashOperationWithdrawal = "withdrawal"
+)
+
+// CashOperationTxParams contains the input parameters of the deposit and withdrawal transactions
+type CashOperationTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Amount is always positive
+	Amount int64 `json:"amount"`
+	// Reference identifies the operation in the external system and can only be used once
+	Reference string `json:"reference"`
+	Note      string `json:"note"`
+	CreatedBy string `json:"created_by"`
+}
+
+// CashOperationTxResult is the result of the deposit and withdrawal transactions
+type CashOperationTxResult struct {
+	Operation     CashOperation `json:"operation"`
+	Account       Account       `json:"account"`
+	SystemAccount Account       `json:"system_account"`
+	Entry         Entry         `json:"entry"`
+	SystemEntry   Entry         `json:"system_entry"`
+}
+
+// DepositTx credits an account with money received by the bank, debiting the cash account of the same currency.
+// It returns ErrAccountNotActive if the account is frozen or closed,
+// and ErrReferenceAlreadyUsed if another operation has the same reference.
+func (store *SQLStore) DepositTx(ctx context.Context, arg CashOperationTxParams) (CashOperationTxResult, error) {
+	return store.cashOperationTx(ctx, CashOperationDeposit, arg.Amount, arg)
+}
+
+// WithdrawTx debits an account with money paid out by the bank, crediting the cash account of the same currency.
+// It returns ErrInsufficientFunds if the account cannot cover the amount, ErrAccountNotActive if it is frozen or closed,
+// and ErrReferenceAlreadyUsed if another operation has the same reference.
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg CashOperationTxParams) (CashOperationTxResult, error) {
+	return store.cashOperationTx(ctx, CashOperationWithdrawal, -arg.Amount, arg)
+}
+
+func (store *SQLStore) cashOperationTx(ctx context.Context, operationType string, amount int64, arg CashOperationTxParams) (CashOperationTxResult, error) {
+	var result CashOperationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		posting, err := postAgainstSystemAccount(ctx, q, arg.AccountID, SystemAccountCash, amount)
+		if err != nil {
+			return err
+		}
+
+		// the account is locked by now, the posting is rolled back if it was frozen or closed
+		if err := checkAccountsActive(posting.Account); err != nil {
+			return err
+		}
+
+		result.Account = posting.Account
+		result.SystemAccount = posting.SystemAccount
+		result.Entry = posting.Entry
+		result.SystemEntry = posting.SystemEntry
+
+		result.Operation, err = q.CreateCashOperation(ctx, CreateCashOperationParams{
+			Type:            operationType,
+			AccountID:       posting.Account.ID,
+			SystemAccountID: posting.SystemAccount.ID,
+			Amount:          arg.Amount,
+			Reference:       arg.Reference,
+			Note:            arg.Note,
+			EntryID:         posting.Entry.ID,
+			SystemEntryID:   posting.SystemEntry.ID,
+			CreatedBy:       arg.CreatedBy,
+		})
+		if ErrorCode(err) == UniqueViolation {
+			return fmt.Errorf("%w: %s", ErrReferenceAlreadyUsed, arg.Reference)
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// systemPosting is the outcome of posting an amount against one of the bank's internal accounts
+type systemPosting struct {
+	Account       Account
+	SystemAccount Account
+	Entry         Entry
+	SystemEntry   Entry
+}
+
+// postAgainstSystemAccount adds a signed amount to an account and the opposite amount to the bank account
+// with the given purpose in the same currency, so every balance keeps matching the sum of its entries.
+// Whichever side is debited must be able to cover the amount.
+func postAgainstSystemAccount(ctx context.Context, q *Queries, accountID int64, purpose string, amount int64) (systemPosting, error) {
+	var result systemPosting
+
+	account, err := q.GetAccount(ctx, accountID)
+	if err != nil {
+		return result, err
+	}
+
+	system, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+		Purpose:  purpose,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// lock both accounts in the same order as addMoney to avoid deadlocks
+	account, systemAccount, err := lockAccounts(ctx, q, account.ID, system.AccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if amount < 0 {
+		err = checkSufficientFunds(account, -amount)
+	} else {
+		err = checkSufficientFunds(systemAccount, amount)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.SystemEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: systemAccount.ID,
+		Amount:    -amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if account.ID < systemAccount.ID {
+		result.Account, result.SystemAccount, err = addMoney(ctx, q, account.ID, systemAccount.ID, amount, -amount)
+	} else {
+		result.SystemAccount, result.Account, err = addMoney(ctx, q, systemAccount.ID, account.ID, -amount, amount)
+	}
+
+	return result, err
+}
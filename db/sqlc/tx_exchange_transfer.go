@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/money"
+	"github.com/leedrum/simplebank/util"
+)
+
+// ExchangeTransferTxParams contains the input parameters of the cross-currency transfer transaction
+type ExchangeTransferTxParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// Amount is in the currency of the from account
+	Amount  int64     `json:"amount"`
+	QuoteID uuid.UUID `json:"quote_id"`
+	// Username must be the user the quote was issued to
+	Username string `json:"username"`
+	// IdempotencyKey makes retries of the same request by Username return the first result
+	IdempotencyKey string `json:"idempotency_key"`
+	// ApprovalDuration is how long a transfer above the approval threshold waits for a banker
+	ApprovalDuration time.Duration `json:"approval_duration"`
+}
+
+// ExchangeTransferTx moves money between accounts held in different currencies at the rate locked by a quote.
+// The from account is debited Amount in its own currency and the to account is credited the converted amount
+// in its currency, both recorded on the transfer row together with the rate.
+// A quote can only be used once, by the user it was issued to, before it expires.
+// The exchange fee of the from currency is charged to the from account on top of the amount.
+// A transfer above the approval threshold of the from currency uses up the quote but waits for a banker,
+// in which case only PendingApproval is set in the result, and it is executed at the rate of the quote once approved.
+func (store *SQLStore) ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = TransferTxResult{}
+
+		var err error
+
+		if arg.IdempotencyKey != "" {
+			requestHash, err := hashRequest("exchange_transfer", arg.FromAccountID, arg.ToAccountID, arg.Amount, arg.QuoteID)
+			if err != nil {
+				return err
+			}
+
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Username, arg.IdempotencyKey, requestHash, &result)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		quote, err := q.GetExchangeQuoteForUpdate(ctx, arg.QuoteID)
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return fmt.Errorf("%w: quote [%s] not found", ErrExchangeQuoteInvalid, arg.QuoteID)
+			}
+			return err
+		}
+
+		// take the accounts in the same order as transferMoney, which locks them again
+		fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkExchangeQuote(quote, arg.Username, fromAccount.Currency, toAccount.Currency); err != nil {
+			return err
+		}
+
+		// checked before asking for an approval, which would fail once approved
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if err := checkTransferLimits(ctx, q, fromAccount, arg.Amount); err != nil {
+			return err
+		}
+
+		toAmount, err := convertAmount(arg.Amount, fromAccount.Currency, toAccount.Currency, quote.Rate)
+		if err != nil {
+			return err
+		}
+
+		err = q.MarkExchangeQuoteUsed(ctx, quote.ID)
+		if err != nil {
+			return err
+		}
+
+		quoteID := pgtype.UUID{Bytes: quote.ID, Valid: true}
+		approval, err := requestApprovalIfNeeded(ctx, q, fromAccount, CreateTransferApprovalParams{
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			RequestedBy: arg.Username,
+			ExpiresAt:   time.Now().Add(arg.ApprovalDuration),
+			QuoteID:     quoteID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if approval != nil {
+			result.PendingApproval = approval
+		} else {
+			result, err = transferMoneyWithFee(ctx, q, util.FeeTypeExchange, fromAccount, CreateTransferParams{
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   arg.ToAccountID,
+				Amount:        arg.Amount,
+				ToAmount:      toAmount,
+				ExchangeRate:  quote.Rate,
+				QuoteID:       quoteID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.IdempotencyKey != "" {
+			return saveIdempotentResponse(ctx, q, arg.Username, arg.IdempotencyKey, result)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// checkExchangeQuote makes sure the quote can still be used by username for a transfer between the two currencies
+func checkExchangeQuote(quote ExchangeQuote, username string, fromCurrency string, toCurrency string) error {
+	if quote.Username != username {
+		return fmt.Errorf("%w: quote [%s] was not issued to user [%s]", ErrExchangeQuoteInvalid, quote.ID, username)
+	}
+
+	if quote.UsedAt.Valid {
+		return fmt.Errorf("%w: quote [%s] was already used", ErrExchangeQuoteInvalid, quote.ID)
+	}
+
+	if quote.FromCurrency != fromCurrency || quote.ToCurrency != toCurrency {
+		return fmt.Errorf(
+			"%w: quote [%s] is for %s to %s, accounts are %s to %s",
+			ErrExchangeQuoteInvalid, quote.ID, quote.FromCurrency, quote.ToCurrency, fromCurrency, toCurrency,
+		)
+	}
+
+	if time.Now().After(quote.ExpiresAt) {
+		return fmt.Errorf("%w: quote [%s] expired at %s", ErrExchangeQuoteExpired, quote.ID, quote.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// convertAmount applies a rate scaled by ExchangeRateScale, rounding down in the bank's favour
+func convertAmount(amount int64, fromCurrency string, toCurrency string, rate int64) (int64, error) {
+	converted, err := money.Convert(money.Money{Amount: amount, Currency: fromCurrency}, toCurrency, rate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrExchangeAmountInvalid, err)
+	}
+
+	if converted.Amount <= 0 {
+		return 0, fmt.Errorf("%w: %d %s at rate %d converts to nothing", ErrExchangeAmountInvalid, amount, fromCurrency, rate)
+	}
+
+	return converted.Amount, nil
+}
@@ -0,0 +1,288 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/money"
+	"github.com/leedrum/simplebank/util"
+)
+
+// Constants for the status of a hold
+const (
+	HoldStatusActive          = "active"
+	HoldStatusPendingApproval = "pending_approval"
+	HoldStatusCaptured        = "captured"
+	HoldStatusVoided          = "voided"
+	HoldStatusExpired         = "expired"
+)
+
+// CreateHoldTxParams contains the input parameters of the hold transaction
+type CreateHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	CreatedBy   string    `json:"created_by"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// HoldTxResult is the result of the transactions that place or release a hold
+type HoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// CaptureHoldTxParams contains the input parameters of the capture transaction
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	Amount int64 `json:"amount"`
+	// ApprovalDuration is how long a capture above the approval threshold waits for a banker
+	ApprovalDuration time.Duration `json:"approval_duration"`
+}
+
+// CaptureHoldTxResult is the result of the capture transaction
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ReleaseHoldTxParams contains the input parameters of the release transaction
+type ReleaseHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Status is HoldStatusVoided or HoldStatusExpired
+	Status string `json:"status"`
+}
+
+// CreateHoldTx reserves funds of an account for a later capture to another account.
+// The hold lowers the available balance without moving money or writing entries.
+// It returns ErrAccountNotActive if either account is frozen or closed,
+// ErrInsufficientFunds if the available balance cannot cover the amount within the overdraft limit,
+// and a TransferLimitError if the amount is above what the account can still send.
+func (store *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, toAccount, err := lockAccounts(ctx, q, arg.AccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountsActive(account, toAccount); err != nil {
+			return err
+		}
+
+		if account.Currency != toAccount.Currency {
+			return fmt.Errorf("%w: account [%d] holds %s, account [%d] holds %s",
+				money.ErrCurrencyMismatch, account.ID, account.Currency, toAccount.ID, toAccount.Currency)
+		}
+
+		if err := checkSufficientFunds(account, arg.Amount); err != nil {
+			return err
+		}
+
+		// the capture is checked again, but a hold it could not fit in today is refused up front
+		if err := checkTransferLimits(ctx, q, account, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   account.ID,
+			ToAccountID: toAccount.ID,
+			Amount:      arg.Amount,
+			CreatedBy:   arg.CreatedBy,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     account.ID,
+			Amount: arg.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CaptureHoldTx moves up to the held amount to the hold's to account as a normal transfer:
+// it counts toward the transfer limits of the account and pays the transfer fee.
+// Above the approval threshold the hold becomes pending_approval and keeps its funds reserved
+// until a banker reviews the capture, in which case only PendingApproval is set in the transfer of the result.
+// A hold is captured once: whatever is not captured is released at the same time.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = CaptureHoldTxResult{}
+
+		hold, err := lockActiveHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		if !hold.ExpiresAt.After(time.Now()) {
+			return fmt.Errorf("%w: hold [%d] expired at %s", ErrHoldExpired, hold.ID, hold.ExpiresAt.Format(time.RFC3339))
+		}
+
+		if arg.Amount > hold.Amount {
+			return fmt.Errorf("%w: hold [%d] has %d, capture %d", ErrHoldCaptureExceeded, hold.ID, hold.Amount, arg.Amount)
+		}
+
+		// take the accounts in the same order as transferMoney, which locks them again
+		fromAccount, _, err := lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTransferLimits(ctx, q, fromAccount, arg.Amount); err != nil {
+			return err
+		}
+
+		approval, err := requestApprovalIfNeeded(ctx, q, fromAccount, CreateTransferApprovalParams{
+			ToAccountID: hold.ToAccountID,
+			Amount:      arg.Amount,
+			RequestedBy: hold.CreatedBy,
+			ExpiresAt:   time.Now().Add(arg.ApprovalDuration),
+		})
+		if err != nil {
+			return err
+		}
+
+		if approval != nil {
+			result.Transfer.PendingApproval = approval
+			result.Hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+				ID:             hold.ID,
+				Status:         HoldStatusPendingApproval,
+				CapturedAmount: arg.Amount,
+				ApprovalID:     pgtype.Int8{Int64: approval.ID, Valid: true},
+			})
+			return err
+		}
+
+		result.Hold, result.Transfer, err = captureHold(ctx, q, hold, arg.Amount, pgtype.Int8{})
+		return err
+	})
+
+	return result, err
+}
+
+// captureHold releases the funds reserved by a locked hold and transfers amount of them with the transfer fee.
+// The accounts of the hold must be locked.
+func captureHold(ctx context.Context, q *Queries, hold Hold, amount int64, approvalID pgtype.Int8) (Hold, TransferTxResult, error) {
+	// the funds are released first, so the capture can spend what the hold reserved
+	fromAccount, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return hold, TransferTxResult{}, err
+	}
+
+	transfer, err := transferMoneyWithFee(ctx, q, util.FeeTypeTransfer, fromAccount, CreateTransferParams{
+		FromAccountID: hold.AccountID,
+		ToAccountID:   hold.ToAccountID,
+		Amount:        amount,
+		ToAmount:      amount,
+		ExchangeRate:  ExchangeRateScale,
+	})
+	if err != nil {
+		return hold, transfer, err
+	}
+
+	hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+		ID:             hold.ID,
+		Status:         HoldStatusCaptured,
+		CapturedAmount: amount,
+		TransferID:     pgtype.Int8{Int64: transfer.Transfer.ID, Valid: true},
+		ApprovalID:     approvalID,
+	})
+	return hold, transfer, err
+}
+
+// lockHoldOfApproval locks the hold waiting for a transfer approval, if any.
+// It returns nil when the approval is not the capture of a hold.
+func lockHoldOfApproval(ctx context.Context, q *Queries, approvalID int64) (*Hold, error) {
+	hold, err := q.GetHoldByApprovalForUpdate(ctx, pgtype.Int8{Int64: approvalID, Valid: true})
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if hold.Status != HoldStatusPendingApproval {
+		return nil, fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+
+	return &hold, nil
+}
+
+// releasePendingHold gives the funds of a hold whose capture was rejected or expired back to the available balance
+func releasePendingHold(ctx context.Context, q *Queries, hold Hold, status string) error {
+	_, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.UpdateHold(ctx, UpdateHoldParams{
+		ID:         hold.ID,
+		Status:     status,
+		ApprovalID: hold.ApprovalID,
+	})
+	return err
+}
+
+// ReleaseHoldTx gives the held funds back to the available balance without moving money,
+// either because the hold was voided or because it expired
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Status == HoldStatusExpired && hold.ExpiresAt.After(time.Now()) {
+			return fmt.Errorf("%w: hold [%d] expires at %s", ErrHoldNotActive, hold.ID, hold.ExpiresAt.Format(time.RFC3339))
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -hold.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+			ID:     hold.ID,
+			Status: arg.Status,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// lockActiveHold locks a hold and makes sure it has not been captured, voided or expired yet
+func lockActiveHold(ctx context.Context, q *Queries, holdID int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+
+	if hold.Status != HoldStatusActive {
+		return hold, fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+
+	return hold, nil
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/money"
+)
+
+// InterestScale is the precision of InterestAccrual.Amount: interest accrues in millionths of the minor unit,
+// so the daily interest of small balances is not lost to rounding before it is posted
+const InterestScale = 1000000
+
+// AccrueInterestParams contains the input parameters of AccrueInterest
+type AccrueInterestParams struct {
+	AccountID int64 `json:"account_id"`
+	// Date is the UTC day the interest is earned on, its time of day is ignored
+	Date time.Time `json:"date"`
+}
+
+// AccrueInterest records the interest an account earned on a day at the yearly rate of its product,
+// using the balance at the end of that day, so a late run accrues the same amount as a timely one.
+// It returns ErrInterestAlreadyAccrued if the account already accrued interest for the day,
+// and an AccountNotActiveError if the account is frozen or closed, which earn no interest.
+func (store *SQLStore) AccrueInterest(ctx context.Context, arg AccrueInterestParams) (InterestAccrual, error) {
+	date := arg.Date.UTC()
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	account, err := store.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+
+	if err := checkAccountsActive(account); err != nil {
+		return InterestAccrual{}, err
+	}
+
+	product, err := store.GetAccountProduct(ctx, account.Product)
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+
+	balance, err := store.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+		At:        date.AddDate(0, 0, 1),
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+
+	amount, err := computeDailyInterest(balance, product.InterestRateBps, date)
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+
+	accrual, err := store.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+		AccountID:       account.ID,
+		AccrualDate:     date,
+		Balance:         balance,
+		InterestRateBps: product.InterestRateBps,
+		Amount:          amount,
+	})
+	if errors.Is(err, ErrorRecordNotFound) {
+		return accrual, fmt.Errorf("%w: account [%d] on %s", ErrInterestAlreadyAccrued, account.ID, date.Format(time.DateOnly))
+	}
+
+	return accrual, err
+}
+
+// PostInterestTxParams contains the input parameters of the interest posting transaction
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Period is the month being posted, any time within it will do.
+	// Interest accrued before the month and not posted yet is posted with it.
+	Period time.Time `json:"period"`
+}
+
+// PostInterestTxResult is the result of the interest posting transaction
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	Account Account         `json:"account"`
+	// Entry and ExpenseEntry are nil when the interest rounded to zero
+	Entry        *Entry `json:"entry,omitempty"`
+	ExpenseEntry *Entry `json:"expense_entry,omitempty"`
+}
+
+// PostInterestTx credits an account with the interest it accrued up to the end of a month,
+// debiting the interest expense account of the same currency.
+// The accrued amounts are summed before rounding to the minor unit of the currency, half to even.
+// It returns ErrInterestAlreadyPosted if the interest of the account was already posted for the month,
+// and an AccountNotActiveError if the account is frozen or closed; its interest stays unposted until it is active again.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	period := arg.Period.UTC()
+	period = time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// claim the month first, so a concurrent posting of the same account waits here and then gives up
+		posting, err := q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID: arg.AccountID,
+			Period:    period,
+		})
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return fmt.Errorf("%w: account [%d] for %s", ErrInterestAlreadyPosted, arg.AccountID, period.Format("2006-01"))
+			}
+			return err
+		}
+
+		amounts, err := q.AssignInterestAccruals(ctx, AssignInterestAccrualsParams{
+			PostingID: pgtype.Int8{Int64: posting.ID, Valid: true},
+			AccountID: arg.AccountID,
+			Before:    period.AddDate(0, 1, 0),
+		})
+		if err != nil {
+			return err
+		}
+
+		var accrued int64
+		for _, amount := range amounts {
+			accrued, err = money.Add(accrued, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		update := UpdateInterestPostingParams{
+			ID:     posting.ID,
+			Amount: roundInterest(accrued),
+		}
+
+		if update.Amount > 0 {
+			systemPosting, err := postAgainstSystemAccount(ctx, q, arg.AccountID, SystemAccountInterest, update.Amount)
+			if err != nil {
+				return err
+			}
+
+			result.Account = systemPosting.Account
+			result.Entry = &systemPosting.Entry
+			result.ExpenseEntry = &systemPosting.SystemEntry
+			update.EntryID = pgtype.Int8{Int64: systemPosting.Entry.ID, Valid: true}
+			update.ExpenseEntryID = pgtype.Int8{Int64: systemPosting.SystemEntry.ID, Valid: true}
+		} else {
+			result.Account, err = q.GetAccount(ctx, arg.AccountID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the account is locked once the interest is added to it, so a concurrent freeze is seen here
+		if err := checkAccountsActive(result.Account); err != nil {
+			return err
+		}
+
+		result.Posting, err = q.UpdateInterestPosting(ctx, update)
+		return err
+	})
+
+	return result, err
+}
+
+// computeDailyInterest returns the interest earned by balance in one day at a yearly rate in basis points,
+// in millionths of the minor unit and rounded down. Negative balances earn nothing.
+func computeDailyInterest(balance int64, rateBps int64, date time.Time) (int64, error) {
+	if balance <= 0 || rateBps <= 0 {
+		return 0, nil
+	}
+
+	interest := new(big.Int).Mul(big.NewInt(balance), big.NewInt(rateBps))
+	interest.Mul(interest, big.NewInt(InterestScale))
+	interest.Quo(interest, big.NewInt(BasisPoints*int64(daysInYear(date))))
+	if !interest.IsInt64() {
+		return 0, fmt.Errorf("%w: interest on %d", money.ErrOverflow, balance)
+	}
+
+	return interest.Int64(), nil
+}
+
+// roundInterest converts accrued interest to minor units, rounding half to even
+// so the rounding does not favour the bank or the customer over many postings
+func roundInterest(accrued int64) int64 {
+	amount, remainder := accrued/InterestScale, accrued%InterestScale
+	if remainder*2 > InterestScale || (remainder*2 == InterestScale && amount%2 == 1) {
+		amount++
+	}
+
+	return amount
+}
+
+// daysInYear is 366 in leap years, so a full year of daily accruals adds up to the yearly rate
+func daysInYear(date time.Time) int {
+	return time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
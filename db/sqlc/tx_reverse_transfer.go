@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// ReverseTransferTxParams contains the input parameters of the reversal transaction
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount is returned to the original from account, in its currency; zero reverses whatever is left
+	Amount     int64  `json:"amount"`
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+	ApprovedBy string `json:"approved_by"`
+}
+
+// ReverseTransferTxResult is the result of the reversal transaction
+type ReverseTransferTxResult struct {
+	Reversal TransferReversal `json:"reversal"`
+	// Transfer is the compensating transfer from the original to account back to the original from account
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ReverseTransferTx undoes all or part of a transfer with a compensating transfer in the opposite direction,
+// so the original entries stay untouched and the ledger keeps its history.
+// Partial refunds add up to at most the original amount; cross-currency transfers are reversed at their original rate.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// locking the original transfer serializes concurrent reversals of it
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		isReversal, err := q.IsReversalTransfer(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		if isReversal {
+			return fmt.Errorf("%w: transfer [%d] is a reversal", ErrTransferIsReversal, original.ID)
+		}
+
+		reversed, err := q.GetTransferReversalTotals(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := original.Amount - reversed.Amount
+		if remaining == 0 {
+			return fmt.Errorf("%w: transfer [%d]", ErrTransferAlreadyReversed, original.ID)
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount > remaining {
+			return fmt.Errorf("%w: transfer [%d] has %d left, reverse %d", ErrReversalAmountExceeded, original.ID, remaining, amount)
+		}
+
+		toAmount := reversalToAmount(original, reversed, amount)
+		if toAmount <= 0 {
+			return fmt.Errorf("%w: %d %s converts to nothing", ErrExchangeAmountInvalid, amount, original.Currency)
+		}
+
+		exchangeRate := int64(ExchangeRateScale)
+		if original.QuoteID.Valid {
+			exchangeRate = inverseExchangeRate(original.ExchangeRate)
+		}
+
+		// the original quote allows the currencies to differ, and is recorded so the reversal shows its rate came from it
+		result.Transfer, err = transferMoney(ctx, q, CreateTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        toAmount,
+			ToAmount:      amount,
+			ExchangeRate:  exchangeRate,
+			QuoteID:       original.QuoteID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID:         original.ID,
+			ReversalTransferID: result.Transfer.Transfer.ID,
+			Amount:             amount,
+			ToAmount:           toAmount,
+			ReasonCode:         arg.ReasonCode,
+			Note:               arg.Note,
+			ApprovedBy:         arg.ApprovedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// reversalToAmount is the share of the original to amount that matches reversing amount more of the original amount.
+// It is computed on the running total so partial refunds that add up to the whole transfer take back exactly its to amount.
+func reversalToAmount(original Transfer, reversed GetTransferReversalTotalsRow, amount int64) int64 {
+	if original.ToAmount == original.Amount {
+		return amount
+	}
+
+	total := new(big.Int).Mul(big.NewInt(original.ToAmount), big.NewInt(reversed.Amount+amount))
+	total.Quo(total, big.NewInt(original.Amount))
+
+	return total.Int64() - reversed.ToAmount
+}
+
+// inverseExchangeRate converts a rate from one currency to another into the rate of the way back
+func inverseExchangeRate(rate int64) int64 {
+	inverse := new(big.Int).Mul(big.NewInt(ExchangeRateScale), big.NewInt(ExchangeRateScale))
+	inverse.Add(inverse, big.NewInt(rate/2))
+	inverse.Quo(inverse, big.NewInt(rate))
+
+	// rates are stored as positive integers, so a huge rate still needs a smallest inverse
+	return max(inverse.Int64(), 1)
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/util"
+)
+
+// Constants for the outcome of a scheduled transfer run
+const (
+	ScheduledRunSucceeded       = "succeeded"
+	ScheduledRunPendingApproval = "pending_approval"
+	ScheduledRunRetrying        = "retrying"
+	ScheduledRunSkipped         = "skipped"
+	ScheduledRunFailed          = "failed"
+)
+
+// ErrScheduledTransferNotDue is returned when a scheduled transfer was paused, deleted
+// or already run by someone else since it was listed as due
+var ErrScheduledTransferNotDue = errors.New("scheduled transfer is not due")
+
+// RunScheduledTransferTxParams contains the input parameters of the scheduled transfer run transaction
+type RunScheduledTransferTxParams struct {
+	ID int64 `json:"id"`
+	// ScheduledFor is the next_run_at the caller saw, so the same occurrence never runs twice
+	ScheduledFor time.Time `json:"scheduled_for"`
+	// NextRunAt is the following occurrence of the schedule
+	NextRunAt time.Time `json:"next_run_at"`
+	// RetryAt is when to try again if the account cannot cover the amount and the policy is retry
+	RetryAt    time.Time `json:"retry_at"`
+	MaxRetries int32     `json:"max_retries"`
+	// ApprovalDuration is how long an occurrence above the approval threshold waits for a banker
+	ApprovalDuration time.Duration `json:"approval_duration"`
+}
+
+// RunScheduledTransferTxResult is the result of the scheduled transfer run transaction
+type RunScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	Transfer          TransferTxResult     `json:"transfer"`
+}
+
+// RunScheduledTransferTx executes one due occurrence of a scheduled transfer and records its outcome.
+// If the from account cannot cover the amount, the occurrence is retried at RetryAt when the policy allows it
+// and the retry still comes before the next occurrence, otherwise it is skipped.
+// An occurrence that exceeds a transfer limit of the from account is skipped.
+// An occurrence above the approval threshold waits for a banker like any transfer, and the schedule moves on.
+// Any other error is returned without recording a run, so the caller can record the failure.
+func (store *SQLStore) RunScheduledTransferTx(ctx context.Context, arg RunScheduledTransferTxParams) (RunScheduledTransferTxResult, error) {
+	var result RunScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = RunScheduledTransferTxResult{}
+
+		scheduled, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			if errors.Is(err, ErrorRecordNotFound) {
+				return ErrScheduledTransferNotDue
+			}
+			return err
+		}
+		if !scheduled.IsActive || !scheduled.NextRunAt.Equal(arg.ScheduledFor) {
+			return ErrScheduledTransferNotDue
+		}
+
+		status := ScheduledRunSucceeded
+		runError := ""
+		update := UpdateScheduledTransferParams{
+			ID:         scheduled.ID,
+			NextRunAt:  pgtype.Timestamptz{Time: arg.NextRunAt, Valid: true},
+			RetryCount: pgtype.Int4{Int32: 0, Valid: true},
+		}
+
+		// take the accounts in the same order as transferMoney, which locks them again
+		fromAccount, toAccount, err := lockAccounts(ctx, q, scheduled.FromAccountID, scheduled.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		// checked before asking for an approval, which would fail once approved
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		err = checkTransferLimits(ctx, q, fromAccount, scheduled.Amount)
+		if err == nil {
+			result.Transfer.PendingApproval, err = requestApprovalIfNeeded(ctx, q, fromAccount, CreateTransferApprovalParams{
+				ToAccountID: scheduled.ToAccountID,
+				Amount:      scheduled.Amount,
+				RequestedBy: scheduled.Owner,
+				ExpiresAt:   time.Now().Add(arg.ApprovalDuration),
+			})
+		}
+		if err == nil && result.Transfer.PendingApproval == nil {
+			result.Transfer, err = transferMoneyWithFee(ctx, q, util.FeeTypeTransfer, fromAccount, CreateTransferParams{
+				FromAccountID: scheduled.FromAccountID,
+				ToAccountID:   scheduled.ToAccountID,
+				Amount:        scheduled.Amount,
+				ToAmount:      scheduled.Amount,
+				ExchangeRate:  ExchangeRateScale,
+			})
+		}
+		switch {
+		case err == nil && result.Transfer.PendingApproval != nil:
+			status = ScheduledRunPendingApproval
+		case err == nil:
+		case errors.Is(err, ErrTransferLimitExceeded):
+			// retrying later the same day would hit the same limit
+			runError = err.Error()
+			status = ScheduledRunSkipped
+		case errors.Is(err, ErrInsufficientFunds):
+			runError = err.Error()
+			status = ScheduledRunSkipped
+			if canRetryScheduledTransfer(scheduled, arg) {
+				status = ScheduledRunRetrying
+				update.NextRunAt = pgtype.Timestamptz{Time: arg.RetryAt, Valid: true}
+				update.RetryCount = pgtype.Int4{Int32: scheduled.RetryCount + 1, Valid: true}
+			}
+		default:
+			return err
+		}
+
+		transferID := pgtype.Int8{}
+		if status == ScheduledRunSucceeded {
+			transferID = pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true}
+		}
+
+		approvalID := pgtype.Int8{}
+		if status == ScheduledRunPendingApproval {
+			approvalID = pgtype.Int8{Int64: result.Transfer.PendingApproval.ID, Valid: true}
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduled.ID,
+			ScheduledFor:        arg.ScheduledFor,
+			Status:              status,
+			TransferID:          transferID,
+			Error:               runError,
+			ApprovalID:          approvalID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, update)
+		return err
+	})
+
+	return result, err
+}
+
+// canRetryScheduledTransfer reports whether an occurrence that could not be covered should be tried again
+func canRetryScheduledTransfer(scheduled ScheduledTransfer, arg RunScheduledTransferTxParams) bool {
+	return scheduled.InsufficientFundsPolicy == util.InsufficientFundsRetry &&
+		scheduled.RetryCount < arg.MaxRetries &&
+		arg.RetryAt.Before(arg.NextRunAt)
+}
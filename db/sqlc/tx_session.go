@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// RotateSessionTxParams contains the input parameters of the session rotation transaction
+type RotateSessionTxParams struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	Username     string    `json:"username"`
+	RefreshToken string    `json:"refresh_token"`
+	ClientIp     string    `json:"client_ip"`
+	UserAgent    string    `json:"user_agent"`
+	// IssueRefreshToken creates the refresh token handed out in exchange for the consumed one.
+	// It may run more than once when the transaction is retried.
+	IssueRefreshToken func(session Session) (CreateSessionParams, error) `json:"-"`
+}
+
+// RotateSessionTxResult is the result of the session rotation transaction
+type RotateSessionTxResult struct {
+	Session Session `json:"session"`
+}
+
+// RotateSessionTx exchanges the refresh token of a session for a new one in the same family.
+// The old token is marked consumed, and presenting a consumed token again blocks the whole family,
+// since either the client or an attacker holds a stolen copy.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+	var reused Session
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		reused = Session{}
+
+		session, err := q.GetSessionForUpdate(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if session.Username != arg.Username || session.RefreshToken != arg.RefreshToken {
+			return ErrSessionTokenMismatch
+		}
+
+		if session.IsBlocked {
+			return ErrSessionBlocked
+		}
+
+		if session.ConsumedAt.Valid {
+			// the family is blocked in this transaction, the error is returned once it commits
+			if _, err := q.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+				return err
+			}
+
+			reused = session
+			return nil
+		}
+
+		if time.Now().After(session.ExpiresAt) {
+			return ErrSessionExpired
+		}
+
+		if _, err := q.ConsumeSession(ctx, session.ID); err != nil {
+			return err
+		}
+
+		newSession, err := arg.IssueRefreshToken(session)
+		if err != nil {
+			return err
+		}
+
+		newSession.Username = session.Username
+		newSession.ClientIp = arg.ClientIp
+		newSession.UserAgent = arg.UserAgent
+		newSession.FamilyID = session.FamilyID
+		result.Session, err = q.CreateSession(ctx, newSession)
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if reused.ID != uuid.Nil {
+		log.Warn().
+			Str("event", "refresh_token_reuse").
+			Str("username", reused.Username).
+			Str("session_id", reused.ID.String()).
+			Str("family_id", reused.FamilyID.String()).
+			Str("client_ip", arg.ClientIp).
+			Str("user_agent", arg.UserAgent).
+			Msg("consumed refresh token presented again, session family revoked")
+		return result, ErrRefreshTokenReused
+	}
+
+	return result, nil
+}
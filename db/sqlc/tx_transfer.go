@@ -2,15 +2,29 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/money"
+	"github.com/leedrum/simplebank/util"
 )
 
 // TransferTxParams contains the input parameters of the transfer transaction
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Username      string `json:"username"`
+	// IdempotencyKey makes retries of the same request by Username return the first result
+	IdempotencyKey string `json:"idempotency_key"`
+	// ApprovalDuration is how long a transfer above the approval threshold waits for a banker
+	ApprovalDuration time.Duration `json:"approval_duration"`
 }
 
+// ExchangeRateScale is the fixed-point scale of exchange rates, so a rate equal to it converts one to one
+const ExchangeRateScale = money.RateScale
+
 // TransferTxResult is the result of the transfer transaction
 type TransferTxResult struct {
 	Transfer    Transfer `json:"transfer"`
@@ -18,57 +32,84 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee is set when the from account paid a fee on top of the transfer
+	Fee *TransferFee `json:"fee,omitempty"`
+	// PendingApproval is set instead of the other fields when the transfer waits for a banker
+	PendingApproval *TransferApproval `json:"pending_approval,omitempty"`
 }
 
 // TransferTx performs a money transfer from one account to the other.
-// It creates the transfer, add account entries, and update accounts' balance within a database transaction
+// It creates the transfer, add account entries, and update accounts' balance within a database transaction.
+// A transfer above the approval threshold of its currency is not executed but waits for a banker,
+// in which case only PendingApproval is set in the result.
+// The fee of the fee schedule for the currency is charged to the from account on top of the amount.
+// It returns ErrAccountNotActive if either account is frozen or closed,
+// ErrInsufficientFunds if the from account cannot cover the amount and fee within its overdraft limit,
+// and a TransferLimitError if the amount exceeds one of the transfer limits of the from account.
+// When an idempotency key is given, a retry of the same transfer returns the stored result instead of moving money again,
+// and reusing the key for a different transfer returns ErrIdempotencyKeyConflict.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
+		result = TransferTxResult{}
+
 		var err error
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams(arg))
+		if arg.IdempotencyKey != "" {
+			requestHash, err := hashRequest("transfer", arg.FromAccountID, arg.ToAccountID, arg.Amount)
+			if err != nil {
+				return err
+			}
+
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Username, arg.IdempotencyKey, requestHash, &result)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		// take the accounts in the same order as transferMoney, which locks them again
+		fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
 		if err != nil {
 			return err
 		}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
+		// checked before asking for an approval, which would fail once approved
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if err := checkTransferLimits(ctx, q, fromAccount, arg.Amount); err != nil {
 			return err
 		}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+		approval, err := requestApprovalIfNeeded(ctx, q, fromAccount, CreateTransferApprovalParams{
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			RequestedBy: arg.Username,
+			ExpiresAt:   time.Now().Add(arg.ApprovalDuration),
 		})
 		if err != nil {
 			return err
 		}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(
-				ctx,
-				q,
-				arg.FromAccountID,
-				arg.ToAccountID,
-				-arg.Amount,
-				arg.Amount,
-			)
+		if approval != nil {
+			result.PendingApproval = approval
 		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(
-				ctx, q, arg.ToAccountID,
-				arg.FromAccountID,
-				arg.Amount,
-				-arg.Amount,
-			)
+			result, err = transferMoneyWithFee(ctx, q, util.FeeTypeTransfer, fromAccount, CreateTransferParams{
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   arg.ToAccountID,
+				Amount:        arg.Amount,
+				ToAmount:      arg.Amount,
+				ExchangeRate:  ExchangeRateScale,
+			})
+			if err != nil {
+				return err
+			}
 		}
 
-		if err != nil {
-			return err
+		if arg.IdempotencyKey != "" {
+			return saveIdempotentResponse(ctx, q, arg.Username, arg.IdempotencyKey, result)
 		}
 
 		return nil
@@ -77,6 +118,111 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 	return result, err
 }
 
+// transferMoney locks both accounts, then writes the transfer, its two entries and the new balances.
+// arg.Amount leaves the from account and arg.ToAmount arrives at the to account.
+// The currencies are taken from the accounts, which must match unless the transfer has an exchange quote.
+// Both accounts must be active.
+func transferMoney(ctx context.Context, q *Queries, arg CreateTransferParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+		return result, err
+	}
+
+	if !arg.QuoteID.Valid && fromAccount.Currency != toAccount.Currency {
+		return result, fmt.Errorf("%w: account [%d] holds %s, account [%d] holds %s",
+			money.ErrCurrencyMismatch, fromAccount.ID, fromAccount.Currency, toAccount.ID, toAccount.Currency)
+	}
+	arg.Currency = fromAccount.Currency
+	arg.ToCurrency = toAccount.Currency
+
+	if err := checkSufficientFunds(fromAccount, arg.Amount); err != nil {
+		return result, err
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     -arg.Amount,
+		TransferID: pgtype.Int8{Int64: result.Transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     arg.ToAmount,
+		TransferID: pgtype.Int8{Int64: result.Transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(
+			ctx,
+			q,
+			arg.FromAccountID,
+			arg.ToAccountID,
+			-arg.Amount,
+			arg.ToAmount,
+		)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(
+			ctx, q, arg.ToAccountID,
+			arg.FromAccountID,
+			arg.ToAmount,
+			-arg.Amount,
+		)
+	}
+
+	return result, err
+}
+
+// lockAccounts locks both accounts in the same order as addMoney to avoid deadlocks
+func lockAccounts(ctx context.Context, q *Queries, fromAccountID int64, toAccountID int64) (fromAccount Account, toAccount Account, err error) {
+	if fromAccountID < toAccountID {
+		fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+		if err != nil {
+			return
+		}
+		toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+	} else {
+		toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+		if err != nil {
+			return
+		}
+		fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+	}
+	return
+}
+
+// AvailableBalance is the ledger balance minus the funds reserved by active holds
+func (account Account) AvailableBalance() int64 {
+	return account.Balance - account.HeldAmount
+}
+
+// checkSufficientFunds makes sure the account can be debited by amount without going past its overdraft limit,
+// counting funds reserved by holds as already spent
+func checkSufficientFunds(account Account, amount int64) error {
+	available := account.AvailableBalance() + account.OverdraftLimit
+	if available < amount {
+		return fmt.Errorf("%w: account [%d] has %d available, need %d", ErrInsufficientFunds, account.ID, available, amount)
+	}
+
+	return nil
+}
+
 // transfer money from account A to account B
 func addMoney(
 	ctx context.Context,
@@ -99,5 +245,8 @@ func addMoneyAccount(ctx context.Context, q *Queries, accountID int64, amount in
 		ID:     accountID,
 		Amount: amount,
 	})
+	if ErrorCode(err) == CheckViolation {
+		err = fmt.Errorf("%w: account [%d]: %v", ErrInsufficientFunds, accountID, err)
+	}
 	return
 }
@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/leedrum/simplebank/util"
+)
+
+// Constants for the status of a transfer approval
+const (
+	TransferApprovalPending  = "pending_approval"
+	TransferApprovalApproved = "approved"
+	TransferApprovalRejected = "rejected"
+	TransferApprovalExpired  = "expired"
+)
+
+// ReviewTransferApprovalTxParams contains the input parameters of the approve and reject transactions
+type ReviewTransferApprovalTxParams struct {
+	ID         int64  `json:"id"`
+	ReviewedBy string `json:"reviewed_by"`
+	Note       string `json:"note"`
+	// AfterReview runs inside the transaction once the approval was reviewed, so a failure rolls the review back
+	AfterReview func(approval TransferApproval) error `json:"-"`
+}
+
+// ReviewTransferApprovalTxResult is the result of the approve and reject transactions
+type ReviewTransferApprovalTxResult struct {
+	Approval TransferApproval `json:"approval"`
+	// Transfer is only set when the transfer was approved
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ApproveTransferTx executes a transfer that was waiting for approval.
+// The reviewer must not be the user who requested the transfer.
+func (store *SQLStore) ApproveTransferTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error) {
+	return store.reviewTransferApproval(ctx, arg, TransferApprovalApproved)
+}
+
+// RejectTransferTx closes a transfer that was waiting for approval without moving money.
+// The reviewer must not be the user who requested the transfer.
+func (store *SQLStore) RejectTransferTx(ctx context.Context, arg ReviewTransferApprovalTxParams) (ReviewTransferApprovalTxResult, error) {
+	return store.reviewTransferApproval(ctx, arg, TransferApprovalRejected)
+}
+
+func (store *SQLStore) reviewTransferApproval(ctx context.Context, arg ReviewTransferApprovalTxParams, status string) (ReviewTransferApprovalTxResult, error) {
+	var result ReviewTransferApprovalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = ReviewTransferApprovalTxResult{}
+
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if approval.Status != TransferApprovalPending {
+			return fmt.Errorf("%w: transfer approval [%d] is %s", ErrTransferApprovalNotPending, approval.ID, approval.Status)
+		}
+
+		if !approval.ExpiresAt.After(time.Now()) {
+			return fmt.Errorf("%w: transfer approval [%d] expired at %s", ErrTransferApprovalExpired, approval.ID, approval.ExpiresAt.Format(time.RFC3339))
+		}
+
+		if approval.RequestedBy == arg.ReviewedBy {
+			return fmt.Errorf("%w: user [%s] requested transfer approval [%d]", ErrSelfReview, arg.ReviewedBy, approval.ID)
+		}
+
+		review := ReviewTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     status,
+			ReviewedBy: pgtype.Text{String: arg.ReviewedBy, Valid: true},
+			ReviewNote: arg.Note,
+		}
+
+		// a capture waiting for approval keeps its hold until the review
+		hold, err := lockHoldOfApproval(ctx, q, approval.ID)
+		if err != nil {
+			return err
+		}
+
+		if status == TransferApprovalRejected && hold != nil {
+			if err := releasePendingHold(ctx, q, *hold, HoldStatusVoided); err != nil {
+				return err
+			}
+		}
+
+		if status == TransferApprovalApproved {
+			feeType := util.FeeTypeTransfer
+			transfer := CreateTransferParams{
+				FromAccountID: approval.FromAccountID,
+				ToAccountID:   approval.ToAccountID,
+				Amount:        approval.Amount,
+				ToAmount:      approval.Amount,
+				ExchangeRate:  ExchangeRateScale,
+			}
+
+			if approval.QuoteID.Valid {
+				// the quote was used up by the request, so its rate holds even after the quote expired
+				quote, err := q.GetExchangeQuoteForUpdate(ctx, approval.QuoteID.Bytes)
+				if err != nil {
+					return err
+				}
+
+				transfer.ToAmount, err = convertAmount(approval.Amount, quote.FromCurrency, quote.ToCurrency, quote.Rate)
+				if err != nil {
+					return err
+				}
+				transfer.ExchangeRate = quote.Rate
+				transfer.QuoteID = approval.QuoteID
+				feeType = util.FeeTypeExchange
+			}
+
+			// the limits are checked again, the account may have sent more since the request
+			fromAccount, _, err := lockAccounts(ctx, q, approval.FromAccountID, approval.ToAccountID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkTransferLimits(ctx, q, fromAccount, approval.Amount); err != nil {
+				return err
+			}
+
+			if hold != nil {
+				_, result.Transfer, err = captureHold(ctx, q, *hold, approval.Amount, hold.ApprovalID)
+			} else {
+				result.Transfer, err = transferMoneyWithFee(ctx, q, feeType, fromAccount, transfer)
+			}
+			if err != nil {
+				return err
+			}
+
+			review.TransferID = pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Approval, err = q.ReviewTransferApproval(ctx, review)
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterReview != nil {
+			return arg.AfterReview(result.Approval)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ExpireTransferApprovalsTx expires the approvals still pending at now,
+// and releases the holds whose capture was waiting for one of them
+func (store *SQLStore) ExpireTransferApprovalsTx(ctx context.Context, now time.Time) ([]TransferApproval, error) {
+	var approvals []TransferApproval
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		approvals, err = q.ExpireTransferApprovals(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, approval := range approvals {
+			hold, err := lockHoldOfApproval(ctx, q, approval.ID)
+			if err != nil {
+				return err
+			}
+
+			if hold != nil {
+				if err := releasePendingHold(ctx, q, *hold, HoldStatusExpired); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return approvals, err
+}
+
+// requestApprovalIfNeeded records a transfer above the approval threshold of its currency instead of executing it.
+// The from account and currency of arg are taken from fromAccount.
+// It returns nil when the transfer can go ahead, or when no threshold is set for the currency.
+func requestApprovalIfNeeded(ctx context.Context, q *Queries, fromAccount Account, arg CreateTransferApprovalParams) (*TransferApproval, error) {
+	threshold, err := q.GetApprovalThreshold(ctx, fromAccount.Currency)
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if arg.Amount <= threshold.Amount {
+		return nil, nil
+	}
+
+	arg.FromAccountID = fromAccount.ID
+	arg.Currency = fromAccount.Currency
+	approval, err := q.CreateTransferApproval(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &approval, nil
+}
@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAccount loads an account and turns a lookup failure into a gRPC status error
+func (server *Server) getAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+
+		return account, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	return account, nil
+}
+
+// getAuthorizedAccount loads an account that the caller is allowed to see.
+// Bankers can see every account, depositors only their own.
+func (server *Server) getAuthorizedAccount(ctx context.Context, authPayload *token.Payload, accountID int64) (db.Account, error) {
+	account, err := server.getAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if !authPayload.HasPermission(token.PermissionAccountsAdmin) && account.Owner != authPayload.Username {
+		return account, accountNotOwnedError(account.ID, authPayload.Username)
+	}
+
+	return account, nil
+}
+
+// accountNotActiveError turns an AccountNotActiveError into a gRPC status error, and returns nil for other errors
+func accountNotActiveError(err error) error {
+	var notActiveErr *db.AccountNotActiveError
+	if !errors.As(err, &notActiveErr) {
+		return nil
+	}
+
+	return preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+		Type:        "ACCOUNT_NOT_ACTIVE",
+		Subject:     fmt.Sprintf("accounts/%d", notActiveErr.AccountID),
+		Description: err.Error(),
+	}})
+}
+
+func accountNotOwnedError(accountID int64, username string) error {
+	err := fmt.Errorf("account [%d] does not belong to user [%s]", accountID, username)
+	return permissionDeniedError("ACCOUNT_NOT_OWNED", map[string]string{
+		"account_id": strconv.FormatInt(accountID, 10),
+		"username":   username,
+	}, err)
+}
@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountStatusError turns the errors of changing the status of an account into gRPC status errors
+func accountStatusError(err error, accountID int64) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+	}
+
+	violationType := ""
+	switch {
+	case errors.Is(err, db.ErrAccountStatusTransition):
+		violationType = "ACCOUNT_STATUS_TRANSITION"
+	case errors.Is(err, db.ErrAccountBalanceNotZero):
+		violationType = "ACCOUNT_BALANCE_NOT_ZERO"
+	default:
+		return status.Errorf(codes.Internal, "cannot change account status: %v", err)
+	}
+
+	return preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+		Type:        violationType,
+		Subject:     fmt.Sprintf("accounts/%d", accountID),
+		Description: err.Error(),
+	}})
+}
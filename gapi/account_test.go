@@ -0,0 +1,227 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAuthorizedAccount(t *testing.T) {
+	owner := util.RandomOwner()
+	account := randomAccount(owner)
+
+	testCases := []struct {
+		name          string
+		username      string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, got db.Account, err error)
+	}{
+		{
+			name:     "Owner",
+			username: owner,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name:     "Banker",
+			username: util.RandomOwner(),
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: util.RandomOwner(),
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				requireErrorInfo(t, err, codes.PermissionDenied, "ACCOUNT_NOT_OWNED")
+			},
+		},
+		{
+			name:     "NotFound",
+			username: owner,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{}, db.ErrorRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InternalError",
+			username: owner,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{}, fmt.Errorf("connection lost"))
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			payload, err := token.NewPayload(tc.username, tc.role, rolePermissions(tc.role), time.Minute)
+			require.NoError(t, err)
+
+			got, err := server.getAuthorizedAccount(context.Background(), payload, account.ID)
+			tc.checkResponse(t, got, err)
+		})
+	}
+}
+
+func TestValidAccount(t *testing.T) {
+	account := randomAccount(util.RandomOwner())
+	account.Currency = util.USD
+
+	testCases := []struct {
+		name          string
+		currency      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, got db.Account, err error)
+	}{
+		{
+			name:     "OK",
+			currency: util.USD,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name:     "CurrencyMismatch",
+			currency: util.EUR,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				requireFieldViolation(t, err, "to_account_id")
+			},
+		},
+		{
+			name:     "NotFound",
+			currency: util.USD,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{}, db.ErrorRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, got db.Account, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			got, err := server.validAccount(context.Background(), "to_account_id", account.ID, tc.currency)
+			tc.checkResponse(t, got, err)
+		})
+	}
+}
+
+// requireErrorInfo checks the code of a status error and the reason of its ErrorInfo detail
+func requireErrorInfo(t *testing.T, err error, code codes.Code, reason string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			require.Equal(t, reason, info.GetReason())
+			return
+		}
+	}
+
+	require.Failf(t, "missing error info", "status %v has no ErrorInfo detail", st)
+}
+
+// requireFieldViolation checks that err is an InvalidArgument error about field
+func requireFieldViolation(t *testing.T, err error, field string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				if violation.GetField() == field {
+					return
+				}
+			}
+		}
+	}
+
+	require.Failf(t, "missing field violation", "status %v has no violation of %s", st, field)
+}
@@ -5,54 +5,106 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/leedrum/simplebank/token"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	authorizationHeader = "authorization"
 )
 
-func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+// authPayloadKey holds the payload of an authorized access token in the context of a call
+type authPayloadKey struct{}
+
+// authorize checks the access token of a call against the permission declared for its RPC,
+// and returns a context holding the payload of the token
+func (server *Server) authorize(ctx context.Context, method string) (context.Context, error) {
+	permission, ok := rpcPermissions[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no permission declared for %s", method)
+	}
+
+	if permission == permissionPublic {
+		return ctx, nil
+	}
+
+	payload, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !payload.HasPermission(permission) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", permission)
+	}
+
+	return context.WithValue(ctx, authPayloadKey{}, payload), nil
+}
+
+// authorizeUser returns the payload of the access token authorized for the call.
+// Calls through the in-process gateway skip the gRPC interceptors,
+// so they are authorized here against the RPC the gateway annotated the context with.
+func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
+	if payload, ok := ctx.Value(authPayloadKey{}).(*token.Payload); ok {
+		return payload, nil
+	}
+
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "call was not authorized")
+	}
+
+	ctx, err := server.authorize(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, ok := ctx.Value(authPayloadKey{}).(*token.Payload)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "%s is public, it has no authenticated user", method)
+	}
+
+	return payload, nil
+}
+
+// authenticate verifies the access token of the call
+func (server *Server) authenticate(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("metadata is not provided")
+		return nil, unauthenticatedError(fmt.Errorf("metadata is not provided"))
 	}
 
 	values := md.Get(authorizationHeader)
 	if len(values) == 0 {
-		return nil, fmt.Errorf("authorization token is not provided")
+		return nil, unauthenticatedError(fmt.Errorf("authorization token is not provided"))
 	}
 
 	authHeader := values[0]
 	fields := strings.Fields(authHeader)
 	if len(fields) != 2 {
-		return nil, fmt.Errorf("authorization token is not valid")
+		return nil, unauthenticatedError(fmt.Errorf("authorization token is not valid"))
 	}
 
 	if strings.ToLower(fields[0]) != "bearer" {
-		return nil, fmt.Errorf("authorization token must be a bearer token")
+		return nil, unauthenticatedError(fmt.Errorf("authorization token must be a bearer token"))
 	}
 
 	accessToken := fields[1]
 	payload, err := server.tokenMaker.VerifyToken(accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("token is invalid: %w", err)
+		return nil, unauthenticatedError(fmt.Errorf("token is invalid: %w", err))
 	}
 
-	if !hasPermission(payload.Role, accessibleRoles) {
-		return nil, fmt.Errorf("forbidden")
+	revoked, err := server.revocations.IsRevoked(ctx, payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
-	return payload, nil
-}
-
-func hasPermission(userRole string, accessibleRoles []string) bool {
-	for _, role := range accessibleRoles {
-		if userRole == role {
-			return true
-		}
+	if revoked {
+		return nil, unauthenticatedError(fmt.Errorf("token has been revoked"))
 	}
 
-	return false
+	return payload, nil
 }
@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cashOperationRequest is implemented by both the deposit and the withdraw request
+type cashOperationRequest interface {
+	GetAccountId() int64
+	GetAmount() int64
+	GetReference() string
+	GetNote() string
+}
+
+func validateCashOperationRequest(req cashOperationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateReference(req.GetReference()); err != nil {
+		violations = append(violations, fieldViolation("reference", err))
+	}
+
+	if err := val.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
+
+// cashOperationError turns the errors of deposits and withdrawals into gRPC status errors
+func cashOperationError(err error, req cashOperationRequest, operationType string) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "account [%d] not found", req.GetAccountId())
+	}
+
+	if errors.Is(err, db.ErrReferenceAlreadyUsed) {
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	}
+
+	if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+		return notActiveErr
+	}
+
+	if errors.Is(err, db.ErrInsufficientFunds) {
+		return preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+			Type:        "INSUFFICIENT_FUNDS",
+			Subject:     fmt.Sprintf("accounts/%d", req.GetAccountId()),
+			Description: err.Error(),
+		}})
+	}
+
+	return status.Errorf(codes.Internal, "cannot record %s: %v", operationType, err)
+}
@@ -1,7 +1,10 @@
 package gapi
 
 import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/money"
 	pb "github.com/leedrum/simplebank/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -15,3 +18,282 @@ func convertUserToResponse(user db.User) *pb.User {
 		CreatedAt:         timestamppb.New(user.CreatedAt),
 	}
 }
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:                      account.ID,
+		Owner:                   account.Owner,
+		Balance:                 account.Balance,
+		Currency:                account.Currency,
+		CreatedAt:               timestamppb.New(account.CreatedAt),
+		OverdraftLimit:          account.OverdraftLimit,
+		BalanceDecimal:          money.Format(account.Balance, account.Currency),
+		OverdraftLimitDecimal:   money.Format(account.OverdraftLimit, account.Currency),
+		HeldAmount:              account.HeldAmount,
+		AvailableBalance:        account.AvailableBalance(),
+		AvailableBalanceDecimal: money.Format(account.AvailableBalance(), account.Currency),
+		Product:                 account.Product,
+		Status:                  account.Status,
+	}
+}
+
+func convertAccountStatusChange(change db.AccountStatusChange) *pb.AccountStatusChange {
+	return &pb.AccountStatusChange{
+		Id:         change.ID,
+		AccountId:  change.AccountID,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		Reason:     change.Reason,
+		ChangedBy:  change.ChangedBy,
+		CreatedAt:  timestamppb.New(change.CreatedAt),
+	}
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		Username:  session.Username,
+		ClientIp:  session.ClientIp,
+		UserAgent: session.UserAgent,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+func convertAccountProduct(product db.AccountProduct) *pb.AccountProduct {
+	return &pb.AccountProduct{
+		Code:            product.Code,
+		Name:            product.Name,
+		InterestRateBps: product.InterestRateBps,
+		UpdatedAt:       timestamppb.New(product.UpdatedAt),
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:              transfer.ID,
+		FromAccountId:   transfer.FromAccountID,
+		ToAccountId:     transfer.ToAccountID,
+		Amount:          transfer.Amount,
+		CreatedAt:       timestamppb.New(transfer.CreatedAt),
+		ToAmount:        transfer.ToAmount,
+		ExchangeRate:    transfer.ExchangeRate,
+		QuoteId:         convertQuoteID(transfer.QuoteID),
+		Currency:        transfer.Currency,
+		ToCurrency:      transfer.ToCurrency,
+		AmountDecimal:   money.Format(transfer.Amount, transfer.Currency),
+		ToAmountDecimal: money.Format(transfer.ToAmount, transfer.ToCurrency),
+	}
+}
+
+func convertQuoteID(quoteID pgtype.UUID) string {
+	if !quoteID.Valid {
+		return ""
+	}
+
+	return uuid.UUID(quoteID.Bytes).String()
+}
+
+// convertEntry needs the currency of the entry's account, which the entry does not store
+func convertEntry(entry db.Entry, currency string) *pb.Entry {
+	return &pb.Entry{
+		Id:            entry.ID,
+		AccountId:     entry.AccountID,
+		Amount:        entry.Amount,
+		CreatedAt:     timestamppb.New(entry.CreatedAt),
+		Currency:      currency,
+		AmountDecimal: money.Format(entry.Amount, currency),
+	}
+}
+
+func convertExchangeRate(rate db.ExchangeRate) *pb.ExchangeRate {
+	return &pb.ExchangeRate{
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         rate.Rate,
+		UpdatedBy:    rate.UpdatedBy,
+		UpdatedAt:    timestamppb.New(rate.UpdatedAt),
+	}
+}
+
+func convertExchangeQuote(quote db.ExchangeQuote) *pb.ExchangeQuote {
+	return &pb.ExchangeQuote{
+		Id:           quote.ID.String(),
+		FromCurrency: quote.FromCurrency,
+		ToCurrency:   quote.ToCurrency,
+		Rate:         quote.Rate,
+		ExpiresAt:    timestamppb.New(quote.ExpiresAt),
+	}
+}
+
+func convertScheduledTransfer(scheduled db.ScheduledTransfer) *pb.ScheduledTransfer {
+	return &pb.ScheduledTransfer{
+		Id:                      scheduled.ID,
+		Owner:                   scheduled.Owner,
+		FromAccountId:           scheduled.FromAccountID,
+		ToAccountId:             scheduled.ToAccountID,
+		Amount:                  scheduled.Amount,
+		Currency:                scheduled.Currency,
+		AmountDecimal:           money.Format(scheduled.Amount, scheduled.Currency),
+		Schedule:                scheduled.Schedule,
+		InsufficientFundsPolicy: scheduled.InsufficientFundsPolicy,
+		NextRunAt:               timestamppb.New(scheduled.NextRunAt),
+		RetryCount:              scheduled.RetryCount,
+		IsActive:                scheduled.IsActive,
+		CreatedAt:               timestamppb.New(scheduled.CreatedAt),
+		UpdatedAt:               timestamppb.New(scheduled.UpdatedAt),
+	}
+}
+
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	return &pb.ScheduledTransferRun{
+		Id:           run.ID,
+		ScheduledFor: timestamppb.New(run.ScheduledFor),
+		Status:       run.Status,
+		TransferId:   run.TransferID.Int64,
+		Error:        run.Error,
+		CreatedAt:    timestamppb.New(run.CreatedAt),
+		ApprovalId:   run.ApprovalID.Int64,
+	}
+}
+
+// convertHold needs the currency of the held account, which the hold does not store
+func convertHold(hold db.Hold, currency string) *pb.Hold {
+	return &pb.Hold{
+		Id:                    hold.ID,
+		AccountId:             hold.AccountID,
+		ToAccountId:           hold.ToAccountID,
+		Amount:                hold.Amount,
+		CapturedAmount:        hold.CapturedAmount,
+		Currency:              currency,
+		AmountDecimal:         money.Format(hold.Amount, currency),
+		CapturedAmountDecimal: money.Format(hold.CapturedAmount, currency),
+		Status:                hold.Status,
+		TransferId:            hold.TransferID.Int64,
+		CreatedBy:             hold.CreatedBy,
+		ExpiresAt:             timestamppb.New(hold.ExpiresAt),
+		CreatedAt:             timestamppb.New(hold.CreatedAt),
+		UpdatedAt:             timestamppb.New(hold.UpdatedAt),
+		ApprovalId:            hold.ApprovalID.Int64,
+	}
+}
+
+func convertTransferReversal(reversal db.TransferReversal) *pb.TransferReversal {
+	return &pb.TransferReversal{
+		Id:                 reversal.ID,
+		TransferId:         reversal.TransferID,
+		ReversalTransferId: reversal.ReversalTransferID,
+		Amount:             reversal.Amount,
+		ToAmount:           reversal.ToAmount,
+		ReasonCode:         reversal.ReasonCode,
+		Note:               reversal.Note,
+		ApprovedBy:         reversal.ApprovedBy,
+		CreatedAt:          timestamppb.New(reversal.CreatedAt),
+	}
+}
+
+func convertTransferApproval(approval db.TransferApproval) *pb.TransferApproval {
+	rsp := &pb.TransferApproval{
+		Id:            approval.ID,
+		FromAccountId: approval.FromAccountID,
+		ToAccountId:   approval.ToAccountID,
+		Amount:        approval.Amount,
+		Currency:      approval.Currency,
+		AmountDecimal: money.Format(approval.Amount, approval.Currency),
+		Status:        approval.Status,
+		RequestedBy:   approval.RequestedBy,
+		ReviewedBy:    approval.ReviewedBy.String,
+		ReviewNote:    approval.ReviewNote,
+		TransferId:    approval.TransferID.Int64,
+		ExpiresAt:     timestamppb.New(approval.ExpiresAt),
+		CreatedAt:     timestamppb.New(approval.CreatedAt),
+	}
+	if approval.ReviewedAt.Valid {
+		rsp.ReviewedAt = timestamppb.New(approval.ReviewedAt.Time)
+	}
+	if approval.QuoteID.Valid {
+		rsp.QuoteId = uuid.UUID(approval.QuoteID.Bytes).String()
+	}
+
+	return rsp
+}
+
+func convertTransferLimits(limits db.TransferLimits, currency string) *pb.TransferLimit {
+	return &pb.TransferLimit{
+		Currency:       currency,
+		PerTransaction: limits.PerTransaction,
+		Daily:          limits.Daily,
+		Monthly:        limits.Monthly,
+		Overridden:     limits.Overridden,
+	}
+}
+
+// convertCashOperation needs the currency of the account, which the operation does not store
+func convertCashOperation(operation db.CashOperation, currency string) *pb.CashOperation {
+	return &pb.CashOperation{
+		Id:              operation.ID,
+		Type:            operation.Type,
+		AccountId:       operation.AccountID,
+		SystemAccountId: operation.SystemAccountID,
+		Amount:          operation.Amount,
+		Currency:        currency,
+		AmountDecimal:   money.Format(operation.Amount, currency),
+		Reference:       operation.Reference,
+		Note:            operation.Note,
+		EntryId:         operation.EntryID,
+		SystemEntryId:   operation.SystemEntryID,
+		CreatedBy:       operation.CreatedBy,
+		CreatedAt:       timestamppb.New(operation.CreatedAt),
+	}
+}
+
+func convertBalanceAdjustment(adjustment db.BalanceAdjustment, currency string) *pb.BalanceAdjustment {
+	return &pb.BalanceAdjustment{
+		Id:              adjustment.ID,
+		AccountId:       adjustment.AccountID,
+		Amount:          adjustment.Amount,
+		Currency:        currency,
+		AmountDecimal:   money.Format(adjustment.Amount, currency),
+		ReasonCode:      adjustment.ReasonCode,
+		Note:            adjustment.Note,
+		EntryId:         adjustment.EntryID,
+		SuspenseEntryId: adjustment.SuspenseEntryID,
+		CreatedBy:       adjustment.CreatedBy,
+		CreatedAt:       timestamppb.New(adjustment.CreatedAt),
+	}
+}
+
+func convertFeeSchedule(schedule db.FeeSchedule) *pb.FeeSchedule {
+	return &pb.FeeSchedule{
+		Id:           schedule.ID,
+		TransferType: schedule.TransferType,
+		Currency:     schedule.Currency,
+		MinAmount:    schedule.MinAmount,
+		FlatFee:      schedule.FlatFee,
+		PercentBps:   schedule.PercentBps,
+		MinFee:       schedule.MinFee,
+		MaxFee:       schedule.MaxFee,
+		UpdatedBy:    schedule.UpdatedBy,
+		UpdatedAt:    timestamppb.New(schedule.UpdatedAt),
+	}
+}
+
+func convertTransferFee(fee db.TransferFee) *pb.TransferFee {
+	return &pb.TransferFee{
+		FlatFee:       fee.FlatFee,
+		PercentFee:    fee.PercentFee,
+		Amount:        fee.Amount,
+		Currency:      fee.Currency,
+		AmountDecimal: money.Format(fee.Amount, fee.Currency),
+	}
+}
+
+func convertFeeQuote(quote db.FeeQuote) *pb.TransferFee {
+	return &pb.TransferFee{
+		FlatFee:       quote.FlatFee,
+		PercentFee:    quote.PercentFee,
+		Amount:        quote.Amount,
+		Currency:      quote.Currency,
+		AmountDecimal: money.Format(quote.Amount, quote.Currency),
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const errorDomain = "simplebank"
+
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
 	return &errdetails.BadRequest_FieldViolation{
 		Field:       field,
@@ -28,3 +30,47 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthenticated %s", err)
 }
+
+func permissionDeniedError(reason string, metadata map[string]string, err error) error {
+	errorInfo := &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}
+	statusDenied := status.New(codes.PermissionDenied, err.Error())
+
+	statusDetails, detailErr := statusDenied.WithDetails(errorInfo)
+	if detailErr != nil {
+		return statusDenied.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+func resourceExhaustedError(reason string, metadata map[string]string, err error) error {
+	errorInfo := &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}
+	statusExhausted := status.New(codes.ResourceExhausted, err.Error())
+
+	statusDetails, detailErr := statusExhausted.WithDetails(errorInfo)
+	if detailErr != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+func preconditionFailureError(violations []*errdetails.PreconditionFailure_Violation) error {
+	preconditionFailure := &errdetails.PreconditionFailure{Violations: violations}
+	statusFailed := status.New(codes.FailedPrecondition, "failed precondition")
+
+	statusDetails, err := statusFailed.WithDetails(preconditionFailure)
+	if err != nil {
+		return statusFailed.Err()
+	}
+
+	return statusDetails.Err()
+}
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAuthorizedHold loads a hold that the caller is allowed to capture or void.
+// Like a card merchant, only the owner of the receiving account can settle a hold, besides bankers.
+func (server *Server) getAuthorizedHold(ctx context.Context, authPayload *token.Payload, holdID int64) (db.Hold, error) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return hold, status.Errorf(codes.NotFound, "hold [%d] not found", holdID)
+		}
+
+		return hold, status.Errorf(codes.Internal, "cannot get hold: %v", err)
+	}
+
+	_, err = server.getAuthorizedAccount(ctx, authPayload, hold.ToAccountID)
+	if err != nil {
+		return hold, err
+	}
+
+	return hold, nil
+}
+
+// holdError turns the errors of settling a hold into gRPC status errors
+func holdError(err error, holdID int64, action string) error {
+	if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+		return notActiveErr
+	}
+
+	if limitErr := transferLimitError(err); limitErr != nil {
+		return limitErr
+	}
+
+	violationType := ""
+	switch {
+	case errors.Is(err, db.ErrHoldNotActive):
+		violationType = "HOLD_NOT_ACTIVE"
+	case errors.Is(err, db.ErrHoldExpired):
+		violationType = "HOLD_EXPIRED"
+	case errors.Is(err, db.ErrInsufficientFunds):
+		violationType = "INSUFFICIENT_FUNDS"
+	case errors.Is(err, db.ErrHoldCaptureExceeded):
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+	default:
+		return status.Errorf(codes.Internal, "cannot %s hold: %v", action, err)
+	}
+
+	return preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+		Type:        violationType,
+		Subject:     fmt.Sprintf("holds/%d", holdID),
+		Description: err.Error(),
+	}})
+}
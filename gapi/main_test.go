@@ -0,0 +1,89 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/revocation"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:        util.RandomString(32),
+		AccessTokenDuration:      time.Minute,
+		ScopedTokenMaxDuration:   time.Hour,
+		TransferApprovalDuration: time.Hour,
+	}
+
+	// tokens are not revoked, unless a test expects the revocation lookup beforehand
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().
+			GetTokenRevocation(gomock.Any(), gomock.Any()).
+			AnyTimes().
+			Return(db.GetTokenRevocationRow{}, nil)
+	}
+
+	server, err := NewServer(config, store, nil, revocation.NewList(store, time.Minute, nil))
+	require.NoError(t, err)
+
+	return server
+}
+
+// newContextWithBearerToken returns the context of an incoming call carrying an access token of username
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, rolePermissions(role), duration)
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorizationHeader: []string{fmt.Sprintf("%s %s", "bearer", accessToken)},
+	}
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// newAuthorizedContext returns the context a handler gets once AuthInterceptor authorized the call of username
+func newAuthorizedContext(t *testing.T, username string, role string) context.Context {
+	payload, err := token.NewPayload(username, role, rolePermissions(role), time.Minute)
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), authPayloadKey{}, payload)
+}
+
+// rolePermissions returns the permissions granted to role by the migrations
+func rolePermissions(role string) []string {
+	if role == util.BankerRole {
+		return token.AllPermissions
+	}
+
+	return []string{
+		token.PermissionAccountsRead,
+		token.PermissionAccountsWrite,
+		token.PermissionTransfersRead,
+		token.PermissionTransfersWrite,
+		token.PermissionSettingsRead,
+		token.PermissionSessionsRead,
+		token.PermissionSessionsWrite,
+		token.PermissionUsersWrite,
+		token.PermissionTokensWrite,
+	}
+}
+
+func randomAccount(owner string) db.Account {
+	return db.Account{
+		ID:       int64(rand.Intn(1000) + 1),
+		Owner:    owner,
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+		Status:   util.AccountStatusActive,
+	}
+}
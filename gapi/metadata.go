@@ -3,7 +3,9 @@ package gapi
 import (
 	"context"
 	"log"
+	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -12,6 +14,7 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
 	userAgentHeader            = "user-agent"
+	idempotencyKeyHeader       = "idempotency-key"
 )
 
 type MetaData struct {
@@ -46,3 +49,25 @@ func (server *Server) extractMetadata(ctx context.Context) *MetaData {
 
 	return metaData
 }
+
+// extractIdempotencyKey returns the idempotency key sent by the client, if any
+func extractIdempotencyKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			return keys[0]
+		}
+	}
+
+	return ""
+}
+
+// IncomingHeaderMatcher forwards the HTTP headers the gRPC handlers read from metadata,
+// on top of the ones forwarded by the gateway by default
+func IncomingHeaderMatcher(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case idempotencyKeyHeader:
+		return idempotencyKeyHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/grpc"
+)
+
+// permissionPublic marks the RPCs that can be called without an access token
+const permissionPublic = ""
+
+// rpcPermissions declares the permission required by each RPC.
+// An RPC missing from here is denied to everyone, so a new one must be declared to be reachable.
+var rpcPermissions = map[string]string{
+	pb.SimpleBank_CreateUser_FullMethodName:       permissionPublic,
+	pb.SimpleBank_LoginUser_FullMethodName:        permissionPublic,
+	pb.SimpleBank_VerifyEmail_FullMethodName:      permissionPublic,
+	pb.SimpleBank_LogoutUser_FullMethodName:       permissionPublic,
+	pb.SimpleBank_RenewAccessToken_FullMethodName: permissionPublic,
+
+	pb.SimpleBank_UpdateUser_FullMethodName:        token.PermissionUsersWrite,
+	pb.SimpleBank_CreateScopedToken_FullMethodName: token.PermissionTokensWrite,
+
+	pb.SimpleBank_CreateAccount_FullMethodName:            token.PermissionAccountsWrite,
+	pb.SimpleBank_GetAccount_FullMethodName:               token.PermissionAccountsRead,
+	pb.SimpleBank_ListAccounts_FullMethodName:             token.PermissionAccountsRead,
+	pb.SimpleBank_DeleteAccount_FullMethodName:            token.PermissionAccountsWrite,
+	pb.SimpleBank_ListEntries_FullMethodName:              token.PermissionAccountsRead,
+	pb.SimpleBank_ListAccountProducts_FullMethodName:      token.PermissionAccountsRead,
+	pb.SimpleBank_ListAccountStatusChanges_FullMethodName: token.PermissionAccountsRead,
+	pb.SimpleBank_UpdateAccountStatus_FullMethodName:      token.PermissionAccountsAdmin,
+	pb.SimpleBank_CreateHold_FullMethodName:               token.PermissionAccountsWrite,
+	pb.SimpleBank_CaptureHold_FullMethodName:              token.PermissionAccountsWrite,
+	pb.SimpleBank_VoidHold_FullMethodName:                 token.PermissionAccountsWrite,
+	pb.SimpleBank_Deposit_FullMethodName:                  token.PermissionAccountsAdmin,
+	pb.SimpleBank_Withdraw_FullMethodName:                 token.PermissionAccountsAdmin,
+	pb.SimpleBank_GetCashOperation_FullMethodName:         token.PermissionAccountsAdmin,
+	pb.SimpleBank_AdjustBalance_FullMethodName:            token.PermissionAccountsAdmin,
+
+	pb.SimpleBank_CreateTransfer_FullMethodName:          token.PermissionTransfersWrite,
+	pb.SimpleBank_GetTransfer_FullMethodName:             token.PermissionTransfersRead,
+	pb.SimpleBank_ListTransfers_FullMethodName:           token.PermissionTransfersRead,
+	pb.SimpleBank_CreateExchangeQuote_FullMethodName:     token.PermissionTransfersWrite,
+	pb.SimpleBank_EstimateTransferFee_FullMethodName:     token.PermissionTransfersRead,
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName: token.PermissionTransfersWrite,
+	pb.SimpleBank_GetScheduledTransfer_FullMethodName:    token.PermissionTransfersRead,
+	pb.SimpleBank_ListScheduledTransfers_FullMethodName:  token.PermissionTransfersRead,
+	pb.SimpleBank_UpdateScheduledTransfer_FullMethodName: token.PermissionTransfersWrite,
+	pb.SimpleBank_DeleteScheduledTransfer_FullMethodName: token.PermissionTransfersWrite,
+	pb.SimpleBank_GetTransferLimit_FullMethodName:        token.PermissionTransfersRead,
+	pb.SimpleBank_ReverseTransfer_FullMethodName:         token.PermissionTransfersAdmin,
+	pb.SimpleBank_ListTransferApprovals_FullMethodName:   token.PermissionTransfersAdmin,
+	pb.SimpleBank_ApproveTransfer_FullMethodName:         token.PermissionTransfersAdmin,
+	pb.SimpleBank_RejectTransfer_FullMethodName:          token.PermissionTransfersAdmin,
+
+	pb.SimpleBank_ListExchangeRates_FullMethodName: token.PermissionSettingsRead,
+	pb.SimpleBank_SetExchangeRate_FullMethodName:   token.PermissionSettingsAdmin,
+	pb.SimpleBank_ListFeeSchedules_FullMethodName:  token.PermissionSettingsRead,
+	pb.SimpleBank_SetFeeSchedule_FullMethodName:    token.PermissionSettingsAdmin,
+	pb.SimpleBank_DeleteFeeSchedule_FullMethodName: token.PermissionSettingsAdmin,
+	pb.SimpleBank_SetTransferLimit_FullMethodName:  token.PermissionSettingsAdmin,
+
+	pb.SimpleBank_ListSessions_FullMethodName:      token.PermissionSessionsRead,
+	pb.SimpleBank_RevokeSession_FullMethodName:     token.PermissionSessionsWrite,
+	pb.SimpleBank_RevokeAllSessions_FullMethodName: token.PermissionSessionsWrite,
+}
+
+// AuthInterceptor authorizes every gRPC call against the permission declared for its RPC,
+// and hands the payload of the access token to the handler through the context
+func (server *Server) AuthInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	ctx, err = server.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
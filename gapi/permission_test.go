@@ -0,0 +1,161 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		method        string
+		setupContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, handlerCtx context.Context, err error)
+	}{
+		{
+			name:   "PublicWithoutToken",
+			method: pb.SimpleBank_CreateUser_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, handlerCtx)
+				require.Nil(t, handlerCtx.Value(authPayloadKey{}))
+			},
+		},
+		{
+			name:   "DepositorWithPermission",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.NoError(t, err)
+				payload, ok := handlerCtx.Value(authPayloadKey{}).(*token.Payload)
+				require.True(t, ok)
+				require.Equal(t, username, payload.Username)
+			},
+		},
+		{
+			name:   "DepositorMissingPermission",
+			method: pb.SimpleBank_UpdateAccountStatus_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				require.Nil(t, handlerCtx)
+			},
+		},
+		{
+			name:   "BankerWithPermission",
+			method: pb.SimpleBank_UpdateAccountStatus_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, handlerCtx)
+			},
+		},
+		{
+			name:   "ScopedTokenMissingPermission",
+			method: pb.SimpleBank_CreateTransfer_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				accessToken, _, err := tokenMaker.CreateToken(username, util.DepositorRole, []string{token.PermissionTransfersRead}, time.Minute)
+				require.NoError(t, err)
+
+				md := metadata.MD{authorizationHeader: []string{fmt.Sprintf("bearer %s", accessToken)}}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				require.Nil(t, handlerCtx)
+			},
+		},
+		{
+			name:   "UndeclaredMethod",
+			method: "/pb.SimpleBank/Undeclared",
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+				require.Nil(t, handlerCtx)
+			},
+		},
+		{
+			name:   "NoToken",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+				require.Nil(t, handlerCtx)
+			},
+		},
+		{
+			name:   "ExpiredToken",
+			method: pb.SimpleBank_GetAccount_FullMethodName,
+			setupContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, util.DepositorRole, -time.Minute)
+			},
+			checkResponse: func(t *testing.T, handlerCtx context.Context, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+				require.Nil(t, handlerCtx)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req any) (any, error) {
+				handlerCtx = ctx
+				return nil, nil
+			}
+
+			ctx := tc.setupContext(t, server.tokenMaker)
+			_, err := server.AuthInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			tc.checkResponse(t, handlerCtx, err)
+		})
+	}
+}
+
+func TestRPCPermissionsDeclared(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := fmt.Sprintf("/%s/%s", pb.SimpleBank_ServiceDesc.ServiceName, method.MethodName)
+
+		permission, ok := rpcPermissions[fullMethod]
+		require.True(t, ok, "no permission declared for %s", fullMethod)
+
+		if permission != permissionPublic {
+			require.True(t, token.IsSupportedPermission(permission), "unsupported permission %s for %s", permission, fullMethod)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdjustBalance lets a banker correct an account's balance.
+// The change is booked against the bank's suspense account so the ledger stays balanced.
+func (server *Server) AdjustBalance(ctx context.Context, req *pb.AdjustBalanceRequest) (*pb.AdjustBalanceResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateAdjustBalanceRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.AdjustBalanceTx(ctx, db.AdjustBalanceTxParams{
+		AccountID:  req.GetAccountId(),
+		Amount:     req.GetAmount(),
+		ReasonCode: req.GetReasonCode(),
+		Note:       req.GetNote(),
+		CreatedBy:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetAccountId())
+		}
+
+		if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+			return nil, notActiveErr
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+				Type:        "INSUFFICIENT_FUNDS",
+				Subject:     fmt.Sprintf("accounts/%d", req.GetAccountId()),
+				Description: err.Error(),
+			}})
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot adjust balance: %v", err)
+	}
+
+	rsp := &pb.AdjustBalanceResponse{
+		Adjustment: convertBalanceAdjustment(result.Adjustment, result.Account.Currency),
+		Account:    convertAccount(result.Account),
+	}
+
+	return rsp, nil
+}
+
+func validateAdjustBalanceRequest(req *pb.AdjustBalanceRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateAdjustmentAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateAdjustmentReason(req.GetReasonCode()); err != nil {
+		violations = append(violations, fieldViolation("reason_code", err))
+	}
+
+	if err := val.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
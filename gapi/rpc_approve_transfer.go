@@ -0,0 +1,36 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+)
+
+func (server *Server) ApproveTransfer(ctx context.Context, req *pb.ApproveTransferRequest) (*pb.ApproveTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReviewTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ApproveTransferTx(ctx, db.ReviewTransferApprovalTxParams{
+		ID:         req.GetTransferApprovalId(),
+		ReviewedBy: authPayload.Username,
+		Note:       req.GetNote(),
+	})
+	if err != nil {
+		return nil, transferApprovalError(err, req.GetTransferApprovalId(), "approve")
+	}
+
+	rsp := &pb.ApproveTransferResponse{
+		TransferApproval: convertTransferApproval(result.Approval),
+		Transfer:         convertTransfer(result.Transfer.Transfer),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCaptureHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, err := server.getAuthorizedHold(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	amount := req.GetAmount()
+	if amount == 0 {
+		amount = hold.Amount
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID:           hold.ID,
+		Amount:           amount,
+		ApprovalDuration: server.config.TransferApprovalDuration,
+	})
+	if err != nil {
+		return nil, holdError(err, hold.ID, "capture")
+	}
+
+	if approval := result.Transfer.PendingApproval; approval != nil {
+		return &pb.CaptureHoldResponse{
+			Hold:            convertHold(result.Hold, approval.Currency),
+			PendingApproval: convertTransferApproval(*approval),
+		}, nil
+	}
+
+	rsp := &pb.CaptureHoldResponse{
+		Hold:     convertHold(result.Hold, result.Transfer.Transfer.Currency),
+		Transfer: convertTransfer(result.Transfer.Transfer),
+	}
+
+	return rsp, nil
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.GetAmount() != 0 {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	product := util.AccountProductChecking
+	if req.GetProduct() != "" {
+		if _, err := server.store.GetAccountProduct(ctx, req.GetProduct()); err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+					fieldViolation("product", fmt.Errorf("unknown account product")),
+				})
+			}
+
+			return nil, status.Errorf(codes.Internal, "cannot get account product: %v", err)
+		}
+		product = req.GetProduct()
+	}
+
+	arg := db.CreateAccountParams{
+		Owner:    authPayload.Username,
+		Currency: req.GetCurrency(),
+		Balance:  0,
+		Product:  product,
+	}
+
+	account, err := server.store.CreateAccount(ctx, arg)
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			return nil, status.Errorf(codes.AlreadyExists, "cannot create account: %v", err)
+		case db.ForeignKeyViolation:
+			return nil, status.Errorf(codes.NotFound, "user [%s] not found", authPayload.Username)
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot create account: %v", err)
+	}
+
+	rsp := &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateExchangeQuote(ctx context.Context, req *pb.CreateExchangeQuoteRequest) (*pb.CreateExchangeQuoteResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCurrencyPair(req.GetFromCurrency(), req.GetToCurrency())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rate, err := server.store.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		FromCurrency: req.GetFromCurrency(),
+		ToCurrency:   req.GetToCurrency(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no exchange rate from %s to %s", req.GetFromCurrency(), req.GetToCurrency())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get exchange rate: %v", err)
+	}
+
+	arg := db.CreateExchangeQuoteParams{
+		ID:           uuid.New(),
+		Username:     authPayload.Username,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         rate.Rate,
+		ExpiresAt:    time.Now().Add(server.config.ExchangeQuoteDuration),
+	}
+
+	quote, err := server.store.CreateExchangeQuote(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create exchange quote: %v", err)
+	}
+
+	rsp := &pb.CreateExchangeQuoteResponse{
+		Quote: convertExchangeQuote(quote),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,115 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/money"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateHold(ctx context.Context, req *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	amount := req.GetAmount()
+	if req.GetAmountDecimal() != "" {
+		parsed, err := money.Parse(req.GetAmountDecimal(), req.GetCurrency())
+		if err != nil {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount_decimal", err)})
+		}
+		amount = parsed.Amount
+	}
+
+	account, err := server.validAccount(ctx, "account_id", req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, accountNotOwnedError(account.ID, authPayload.Username)
+	}
+
+	_, err = server.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.CreateHoldTx(ctx, db.CreateHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: req.GetToAccountId(),
+		Amount:      amount,
+		CreatedBy:   authPayload.Username,
+		ExpiresAt:   time.Now().Add(server.config.HoldDuration),
+	})
+	if err != nil {
+		if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+			return nil, notActiveErr
+		}
+
+		if limitErr := transferLimitError(err); limitErr != nil {
+			return nil, limitErr
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+				Type:        "INSUFFICIENT_FUNDS",
+				Subject:     fmt.Sprintf("accounts/%d", account.ID),
+				Description: err.Error(),
+			}})
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot create hold: %v", err)
+	}
+
+	rsp := &pb.CreateHoldResponse{
+		Hold:    convertHold(result.Hold, result.Account.Currency),
+		Account: convertAccount(result.Account),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateHoldRequest(req *pb.CreateHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("cannot hold funds for the same account")))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetAmountDecimal() == "" {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	} else if req.GetAmount() != 0 {
+		violations = append(violations, fieldViolation("amount_decimal", fmt.Errorf("cannot be combined with amount")))
+	} else if err := val.ValidateAmountDecimal(req.GetAmountDecimal(), req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("amount_decimal", err))
+	}
+
+	return violations
+}
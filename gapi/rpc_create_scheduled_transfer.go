@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/money"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	amount := req.GetAmount()
+	if req.GetAmountDecimal() != "" {
+		parsed, err := money.Parse(req.GetAmountDecimal(), req.GetCurrency())
+		if err != nil {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount_decimal", err)})
+		}
+		amount = parsed.Amount
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, accountNotOwnedError(fromAccount.ID, authPayload.Username)
+	}
+
+	_, err = server.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	nextRunAt, err := util.NextRun(req.GetSchedule(), time.Now())
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("schedule", err)})
+	}
+
+	policy := req.GetInsufficientFundsPolicy()
+	if policy == "" {
+		policy = util.InsufficientFundsSkip
+	}
+
+	scheduled, err := server.store.CreateScheduledTransfer(ctx, db.CreateScheduledTransferParams{
+		Owner:                   authPayload.Username,
+		FromAccountID:           fromAccount.ID,
+		ToAccountID:             req.GetToAccountId(),
+		Amount:                  amount,
+		Currency:                req.GetCurrency(),
+		Schedule:                req.GetSchedule(),
+		InsufficientFundsPolicy: policy,
+		NextRunAt:               nextRunAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create scheduled transfer: %v", err)
+	}
+
+	rsp := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduled),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("cannot transfer to the same account")))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetAmountDecimal() == "" {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	} else if req.GetAmount() != 0 {
+		violations = append(violations, fieldViolation("amount_decimal", fmt.Errorf("cannot be combined with amount")))
+	} else if err := val.ValidateAmountDecimal(req.GetAmountDecimal(), req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("amount_decimal", err))
+	}
+
+	if err := val.ValidateSchedule(req.GetSchedule()); err != nil {
+		violations = append(violations, fieldViolation("schedule", err))
+	}
+
+	if req.GetInsufficientFundsPolicy() != "" {
+		if err := val.ValidateInsufficientFundsPolicy(req.GetInsufficientFundsPolicy()); err != nil {
+			violations = append(violations, fieldViolation("insufficient_funds_policy", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateScopedToken issues an access token down-scoped to some of the permissions of the caller,
+// to hand to a third-party integration. It has no session, so it is revoked by revoking all the sessions of the user.
+// It cannot mint other tokens, and it expires no later than the token of the caller.
+func (server *Server) CreateScopedToken(ctx context.Context, req *pb.CreateScopedTokenRequest) (*pb.CreateScopedTokenResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := server.validateCreateScopedTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if !authPayload.HasPermissions(req.GetPermissions()) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot grant permissions the caller does not have")
+	}
+
+	// a scoped token does not outlive the token it is minted with
+	duration := min(req.GetDuration().AsDuration(), time.Until(authPayload.ExpiredAt))
+	if duration <= 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "caller token is about to expire")
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		authPayload.Username,
+		authPayload.Role,
+		req.GetPermissions(),
+		duration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create access token: %v", err)
+	}
+
+	rsp := &pb.CreateScopedTokenResponse{
+		AccessToken:          accessToken,
+		Permissions:          accessPayload.Permissions,
+		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) validateCreateScopedTokenRequest(req *pb.CreateScopedTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateScopedPermissions(req.GetPermissions()); err != nil {
+		violations = append(violations, fieldViolation("permissions", err))
+	}
+
+	if err := val.ValidateTokenDuration(req.GetDuration().AsDuration(), server.config.ScopedTokenMaxDuration); err != nil {
+		violations = append(violations, fieldViolation("duration", err))
+	}
+
+	return violations
+}
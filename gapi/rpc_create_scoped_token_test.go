@@ -0,0 +1,101 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestCreateScopedTokenRPC(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name           string
+		callerDuration time.Duration
+		req            *pb.CreateScopedTokenRequest
+		checkResponse  func(t *testing.T, tokenMaker token.Maker, rsp *pb.CreateScopedTokenResponse, err error)
+	}{
+		{
+			name:           "OK",
+			callerDuration: time.Hour,
+			req: &pb.CreateScopedTokenRequest{
+				Permissions: []string{token.PermissionAccountsRead},
+				Duration:    durationpb.New(time.Minute),
+			},
+			checkResponse: func(t *testing.T, tokenMaker token.Maker, rsp *pb.CreateScopedTokenResponse, err error) {
+				require.NoError(t, err)
+
+				payload, err := tokenMaker.VerifyToken(rsp.GetAccessToken())
+				require.NoError(t, err)
+				require.Equal(t, username, payload.Username)
+				require.Equal(t, []string{token.PermissionAccountsRead}, payload.Permissions)
+				require.WithinDuration(t, time.Now().Add(time.Minute), payload.ExpiredAt, time.Second)
+			},
+		},
+		{
+			name:           "CappedByCallerToken",
+			callerDuration: 10 * time.Second,
+			req: &pb.CreateScopedTokenRequest{
+				Permissions: []string{token.PermissionAccountsRead},
+				Duration:    durationpb.New(time.Hour),
+			},
+			checkResponse: func(t *testing.T, tokenMaker token.Maker, rsp *pb.CreateScopedTokenResponse, err error) {
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now().Add(10*time.Second), rsp.GetAccessTokenExpiresAt().AsTime(), time.Second)
+			},
+		},
+		{
+			name:           "CallerTokenExpiring",
+			callerDuration: -time.Second,
+			req: &pb.CreateScopedTokenRequest{
+				Permissions: []string{token.PermissionAccountsRead},
+				Duration:    durationpb.New(time.Minute),
+			},
+			checkResponse: func(t *testing.T, tokenMaker token.Maker, rsp *pb.CreateScopedTokenResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+				require.Nil(t, rsp)
+			},
+		},
+		{
+			name:           "PermissionNotHeld",
+			callerDuration: time.Hour,
+			req: &pb.CreateScopedTokenRequest{
+				Permissions: []string{token.PermissionAccountsAdmin},
+				Duration:    durationpb.New(time.Minute),
+			},
+			checkResponse: func(t *testing.T, tokenMaker token.Maker, rsp *pb.CreateScopedTokenResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			// the interceptor has verified the token of the caller, which may expire while the call runs
+			payload, err := token.NewPayload(username, util.DepositorRole, rolePermissions(util.DepositorRole), tc.callerDuration)
+			require.NoError(t, err)
+			ctx := context.WithValue(context.Background(), authPayloadKey{}, payload)
+
+			rsp, err := server.CreateScopedToken(ctx, tc.req)
+			tc.checkResponse(t, server.tokenMaker, rsp, err)
+		})
+	}
+}
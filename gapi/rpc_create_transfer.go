@@ -0,0 +1,195 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/money"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotencyKey := extractIdempotencyKey(ctx)
+	violations := validateCreateTransferRequest(req, idempotencyKey)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	amount := req.GetAmount()
+	if req.GetAmountDecimal() != "" {
+		parsed, err := money.Parse(req.GetAmountDecimal(), req.GetCurrency())
+		if err != nil {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount_decimal", err)})
+		}
+		amount = parsed.Amount
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, accountNotOwnedError(fromAccount.ID, authPayload.Username)
+	}
+
+	var result db.TransferTxResult
+	if req.GetQuoteId() == "" {
+		_, err = server.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = server.store.TransferTx(ctx, db.TransferTxParams{
+			FromAccountID:    req.GetFromAccountId(),
+			ToAccountID:      req.GetToAccountId(),
+			Amount:           amount,
+			Username:         authPayload.Username,
+			IdempotencyKey:   idempotencyKey,
+			ApprovalDuration: server.config.TransferApprovalDuration,
+		})
+	} else {
+		// the quote decides which currency the to account must hold
+		_, err = server.getAccount(ctx, req.GetToAccountId())
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = server.store.ExchangeTransferTx(ctx, db.ExchangeTransferTxParams{
+			FromAccountID:    req.GetFromAccountId(),
+			ToAccountID:      req.GetToAccountId(),
+			Amount:           amount,
+			QuoteID:          uuid.MustParse(req.GetQuoteId()),
+			Username:         authPayload.Username,
+			IdempotencyKey:   idempotencyKey,
+			ApprovalDuration: server.config.TransferApprovalDuration,
+		})
+	}
+	if err != nil {
+		if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+			return nil, notActiveErr
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+				Type:        "INSUFFICIENT_FUNDS",
+				Subject:     fmt.Sprintf("accounts/%d", req.GetFromAccountId()),
+				Description: err.Error(),
+			}})
+		}
+
+		if errors.Is(err, db.ErrExchangeQuoteInvalid) || errors.Is(err, db.ErrExchangeQuoteExpired) {
+			violationType := "EXCHANGE_QUOTE_INVALID"
+			if errors.Is(err, db.ErrExchangeQuoteExpired) {
+				violationType = "EXCHANGE_QUOTE_EXPIRED"
+			}
+
+			return nil, preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+				Type:        violationType,
+				Subject:     fmt.Sprintf("exchange_quotes/%s", req.GetQuoteId()),
+				Description: err.Error(),
+			}})
+		}
+
+		if limitErr := transferLimitError(err); limitErr != nil {
+			return nil, limitErr
+		}
+
+		if errors.Is(err, db.ErrExchangeAmountInvalid) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot transfer money: %v", err)
+	}
+
+	if result.PendingApproval != nil {
+		return &pb.CreateTransferResponse{
+			PendingApproval: convertTransferApproval(*result.PendingApproval),
+		}, nil
+	}
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry, result.FromAccount.Currency),
+		ToEntry:     convertEntry(result.ToEntry, result.ToAccount.Currency),
+	}
+	if result.Fee != nil {
+		rsp.Fee = convertTransferFee(*result.Fee)
+	}
+
+	return rsp, nil
+}
+
+// validAccount checks that the account exists and holds money in the given currency.
+// field is the request field the account id came from, used in the error details.
+func (server *Server) validAccount(ctx context.Context, field string, accountID int64, currency string) (db.Account, error) {
+	account, err := server.getAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(field, err)})
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest, idempotencyKey string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("cannot transfer to the same account")))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetAmountDecimal() == "" {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	} else if req.GetAmount() != 0 {
+		violations = append(violations, fieldViolation("amount_decimal", fmt.Errorf("cannot be combined with amount")))
+	} else if err := val.ValidateAmountDecimal(req.GetAmountDecimal(), req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("amount_decimal", err))
+	}
+
+	if req.GetQuoteId() != "" {
+		if err := val.ValidateQuoteID(req.GetQuoteId()); err != nil {
+			violations = append(violations, fieldViolation("quote_id", err))
+		}
+	}
+
+	if err := val.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		violations = append(violations, fieldViolation(idempotencyKeyHeader, err))
+	}
+
+	return violations
+}
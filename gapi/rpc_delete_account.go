@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// DeleteAccount closes an account of the caller, which requires a zero balance.
+// The account is kept with its entries and transfers, and a banker can reopen it.
+// Only a banker can close a frozen account, so the owner cannot get around the freeze.
+func (server *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDeleteAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAuthorizedAccount(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    util.AccountStatusClosed,
+		ChangedBy: authPayload.Username,
+	}
+	if !authPayload.HasPermission(token.PermissionAccountsAdmin) {
+		arg.FromStatus = util.AccountStatusActive
+	}
+
+	result, err := server.store.ChangeAccountStatusTx(ctx, arg)
+	if err != nil {
+		if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+			return nil, notActiveErr
+		}
+		return nil, accountStatusError(err, account.ID)
+	}
+
+	rsp := &pb.DeleteAccountResponse{
+		IsDeleted: true,
+		Account:   convertAccount(result.Account),
+	}
+
+	return rsp, nil
+}
+
+func validateDeleteAccountRequest(req *pb.DeleteAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,33 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteFeeSchedule(ctx context.Context, req *pb.DeleteFeeScheduleRequest) (*pb.DeleteFeeScheduleResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := val.ValidateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	err = server.store.DeleteFeeSchedule(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot delete fee schedule: %v", err)
+	}
+
+	rsp := &pb.DeleteFeeScheduleResponse{
+		IsDeleted: true,
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) EstimateTransferFee(ctx context.Context, req *pb.EstimateTransferFeeRequest) (*pb.EstimateTransferFeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateEstimateTransferFeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.getAuthorizedAccount(ctx, authPayload, req.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.getAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	feeType := util.FeeTypeTransfer
+	if fromAccount.Currency != toAccount.Currency {
+		feeType = util.FeeTypeExchange
+	}
+
+	quote, err := server.store.EstimateTransferFee(ctx, feeType, fromAccount.Currency, req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot estimate transfer fee: %v", err)
+	}
+
+	rsp := &pb.EstimateTransferFeeResponse{
+		Fee: convertFeeQuote(quote),
+	}
+
+	return rsp, nil
+}
+
+func validateEstimateTransferFeeRequest(req *pb.EstimateTransferFeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetCashOperation(ctx context.Context, req *pb.GetCashOperationRequest) (*pb.GetCashOperationResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := val.ValidateReference(req.GetReference()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("reference", err)})
+	}
+
+	operation, err := server.store.GetCashOperationByReference(ctx, req.GetReference())
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no cash operation with reference %s", req.GetReference())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get cash operation: %v", err)
+	}
+
+	account, err := server.getAccount(ctx, operation.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetCashOperationResponse{
+		Operation: convertCashOperation(operation, account.Currency),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,47 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransferLimit(ctx context.Context, req *pb.GetTransferLimitRequest) (*pb.GetTransferLimitResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("account_id", err)})
+	}
+
+	account, err := server.getAuthorizedAccount(ctx, authPayload, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := server.store.GetTransferAllowance(ctx, account)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no transfer limits apply to account [%d]", account.ID)
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get transfer limits: %v", err)
+	}
+
+	rsp := &pb.GetTransferLimitResponse{
+		Limit:            convertTransferLimits(allowance.Limits, account.Currency),
+		DailyRemaining:   allowance.DailyRemaining,
+		MonthlyRemaining: allowance.MonthlyRemaining,
+		Remaining:        allowance.Remaining(),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountProducts(ctx context.Context, req *pb.ListAccountProductsRequest) (*pb.ListAccountProductsResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := server.store.ListAccountProducts(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list account products: %v", err)
+	}
+
+	rsp := &pb.ListAccountProductsResponse{
+		AccountProducts: make([]*pb.AccountProduct, 0, len(products)),
+	}
+	for _, product := range products {
+		rsp.AccountProducts = append(rsp.AccountProducts, convertAccountProduct(product))
+	}
+
+	return rsp, nil
+}
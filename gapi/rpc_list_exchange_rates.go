@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := server.store.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list exchange rates: %v", err)
+	}
+
+	rsp := &pb.ListExchangeRatesResponse{
+		ExchangeRates: make([]*pb.ExchangeRate, 0, len(rates)),
+	}
+	for _, rate := range rates {
+		rsp.ExchangeRates = append(rsp.ExchangeRates, convertExchangeRate(rate))
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListFeeSchedules(ctx context.Context, req *pb.ListFeeSchedulesRequest) (*pb.ListFeeSchedulesResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := server.store.ListFeeSchedules(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list fee schedules: %v", err)
+	}
+
+	rsp := &pb.ListFeeSchedulesResponse{
+		FeeSchedules: make([]*pb.FeeSchedule, 0, len(schedules)),
+	}
+	for _, schedule := range schedules {
+		rsp.FeeSchedules = append(rsp.FeeSchedules, convertFeeSchedule(schedule))
+	}
+
+	return rsp, nil
+}
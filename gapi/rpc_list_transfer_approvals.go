@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListTransferApprovals(ctx context.Context, req *pb.ListTransferApprovalsRequest) (*pb.ListTransferApprovalsResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateListTransferApprovalsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	approvals, err := server.store.ListPendingTransferApprovals(ctx, db.ListPendingTransferApprovalsParams{
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list transfer approvals: %v", err)
+	}
+
+	rsp := &pb.ListTransferApprovalsResponse{
+		TransferApprovals: make([]*pb.TransferApproval, 0, len(approvals)),
+	}
+	for _, approval := range approvals {
+		rsp.TransferApprovals = append(rsp.TransferApprovals, convertTransferApproval(approval))
+	}
+
+	return rsp, nil
+}
+
+func validateListTransferApprovalsRequest(req *pb.ListTransferApprovalsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/leedrum/simplebank/db/sqlc"
 	"github.com/leedrum/simplebank/pb"
 	"github.com/leedrum/simplebank/util"
@@ -33,18 +34,26 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.NotFound, "invalid password")
 	}
 
+	permissions, err := server.store.ListRolePermissions(ctx, user.Role)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get permissions: %v", err)
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
+		permissions,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot create access token: %v", err)
 	}
 
+	// the refresh token grants no permission, so it cannot be used as an access token
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
+		nil,
 		server.config.RefreshTokenDuration,
 	)
 
@@ -54,12 +63,17 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	metaData := server.extractMetadata(ctx)
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
 		ID:           refreshPayload.ID,
+		FamilyID:     refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		ExpiresAt:    refreshPayload.ExpiredAt,
 		ClientIp:     metaData.ClientIP,
 		UserAgent:    metaData.UserAgent,
 		IsBlocked:    false,
+		AccessTokenID: pgtype.UUID{
+			Bytes: accessPayload.ID,
+			Valid: true,
+		},
 	})
 
 	if err != nil {
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/worker"
+)
+
+func (server *Server) RejectTransfer(ctx context.Context, req *pb.RejectTransferRequest) (*pb.RejectTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReviewTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.RejectTransferTx(ctx, db.ReviewTransferApprovalTxParams{
+		ID:         req.GetTransferApprovalId(),
+		ReviewedBy: authPayload.Username,
+		Note:       req.GetNote(),
+		AfterReview: func(approval db.TransferApproval) error {
+			taskPayload := worker.PayloadSendTransferApprovalEmail{
+				TransferApprovalID: approval.ID,
+			}
+			taskOpts := []asynq.Option{
+				asynq.ProcessIn(10 * time.Second),
+				asynq.MaxRetry(10),
+				asynq.Queue(worker.QueueCritical),
+			}
+			return server.taskDistributor.DistributeTaskSendTransferApprovalEmail(ctx, taskPayload, taskOpts...)
+		},
+	})
+	if err != nil {
+		return nil, transferApprovalError(err, req.GetTransferApprovalId(), "reject")
+	}
+
+	rsp := &pb.RejectTransferResponse{
+		TransferApproval: convertTransferApproval(result.Approval),
+	}
+
+	return rsp, nil
+}
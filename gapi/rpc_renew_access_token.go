@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RenewAccessToken exchanges a refresh token for a new access token and a new refresh token,
+// the old refresh token cannot be used again
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	// the permissions are read again, so changes to the ones of the role apply from the next renewal
+	permissions, err := server.store.ListRolePermissions(ctx, refreshPayload.Role)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get permissions: %v", err)
+	}
+
+	// the access token is created first, so the new session records it and revoking the session revokes it too
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		refreshPayload.Username,
+		refreshPayload.Role,
+		permissions,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create access token: %v", err)
+	}
+
+	metaData := server.extractMetadata(ctx)
+	var refreshToken string
+	var newRefreshPayload *token.Payload
+	result, err := server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		SessionID:    refreshPayload.ID,
+		Username:     refreshPayload.Username,
+		RefreshToken: req.GetRefreshToken(),
+		ClientIp:     metaData.ClientIP,
+		UserAgent:    metaData.UserAgent,
+		IssueRefreshToken: func(session db.Session) (db.CreateSessionParams, error) {
+			// the new token keeps the expiry of the login, so rotating cannot extend a session
+			var err error
+			refreshToken, newRefreshPayload, err = server.tokenMaker.CreateToken(
+				refreshPayload.Username,
+				refreshPayload.Role,
+				nil,
+				time.Until(session.ExpiresAt),
+			)
+			if err != nil {
+				return db.CreateSessionParams{}, err
+			}
+
+			return db.CreateSessionParams{
+				ID:           newRefreshPayload.ID,
+				RefreshToken: refreshToken,
+				ExpiresAt:    newRefreshPayload.ExpiredAt,
+				AccessTokenID: pgtype.UUID{
+					Bytes: accessPayload.ID,
+					Valid: true,
+				},
+			}, nil
+		},
+	})
+	if errors.Is(err, db.ErrRefreshTokenReused) {
+		// the family is blocked, the access tokens issued with it must not outlive it
+		if err := server.revocations.RevokeSessionTokens(ctx, refreshPayload.ID); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.Unauthenticated, "session not found")
+		}
+
+		if errors.Is(err, db.ErrSessionBlocked) ||
+			errors.Is(err, db.ErrSessionExpired) ||
+			errors.Is(err, db.ErrSessionTokenMismatch) ||
+			errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot renew session: %v", err)
+	}
+
+	rsp := &pb.RenewAccessTokenResponse{
+		SessionId:             result.Session.ID.String(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}
+
+	return rsp, nil
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetRefreshToken() == "" {
+		violations = append(violations, fieldViolation("refresh_token", fmt.Errorf("must not be empty")))
+	}
+
+	return violations
+}
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReverseTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: req.GetTransferId(),
+		Amount:     req.GetAmount(),
+		ReasonCode: req.GetReasonCode(),
+		Note:       req.GetNote(),
+		ApprovedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "transfer [%d] not found", req.GetTransferId())
+		}
+
+		if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+			return nil, notActiveErr
+		}
+
+		violationType := ""
+		switch {
+		case errors.Is(err, db.ErrTransferAlreadyReversed):
+			violationType = "TRANSFER_ALREADY_REVERSED"
+		case errors.Is(err, db.ErrTransferIsReversal):
+			violationType = "TRANSFER_IS_REVERSAL"
+		case errors.Is(err, db.ErrInsufficientFunds):
+			violationType = "INSUFFICIENT_FUNDS"
+		case errors.Is(err, db.ErrReversalAmountExceeded), errors.Is(err, db.ErrExchangeAmountInvalid):
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+		default:
+			return nil, status.Errorf(codes.Internal, "cannot reverse transfer: %v", err)
+		}
+
+		return nil, preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+			Type:        violationType,
+			Subject:     fmt.Sprintf("transfers/%d", req.GetTransferId()),
+			Description: err.Error(),
+		}})
+	}
+
+	rsp := &pb.ReverseTransferResponse{
+		Reversal: convertTransferReversal(result.Reversal),
+		Transfer: convertTransfer(result.Transfer.Transfer),
+	}
+
+	return rsp, nil
+}
+
+func validateReverseTransferRequest(req *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if req.GetAmount() != 0 {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if err := val.ValidateReversalReason(req.GetReasonCode()); err != nil {
+		violations = append(violations, fieldViolation("reason_code", err))
+	}
+
+	if err := val.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateRevokeAllSessionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	username, err := sessionOwner(authPayload, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := server.store.BlockUserSessions(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot revoke sessions: %v", err)
+	}
+
+	// the access tokens already issued are rejected too, which locks the user out until the next login
+	if err := server.revocations.RevokeUserTokens(ctx, username, time.Now()); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	rsp := &pb.RevokeAllSessionsResponse{
+		RevokedCount: revoked,
+	}
+
+	return rsp, nil
+}
+
+func validateRevokeAllSessionsRequest(req *pb.RevokeAllSessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetUsername() != "" {
+		if err := val.ValidateUsername(req.GetUsername()); err != nil {
+			violations = append(violations, fieldViolation("username", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetExchangeRate(ctx context.Context, req *pb.SetExchangeRateRequest) (*pb.SetExchangeRateResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetExchangeRateRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.UpsertExchangeRateParams{
+		FromCurrency: req.GetFromCurrency(),
+		ToCurrency:   req.GetToCurrency(),
+		Rate:         req.GetRate(),
+		UpdatedBy:    authPayload.Username,
+	}
+
+	rate, err := server.store.UpsertExchangeRate(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set exchange rate: %v", err)
+	}
+
+	rsp := &pb.SetExchangeRateResponse{
+		ExchangeRate: convertExchangeRate(rate),
+	}
+
+	return rsp, nil
+}
+
+func validateSetExchangeRateRequest(req *pb.SetExchangeRateRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validateCurrencyPair(req.GetFromCurrency(), req.GetToCurrency())
+
+	if err := val.ValidateExchangeRate(req.GetRate()); err != nil {
+		violations = append(violations, fieldViolation("rate", err))
+	}
+
+	return violations
+}
+
+// validateCurrencyPair checks the from_currency and to_currency fields of exchange requests
+func validateCurrencyPair(fromCurrency string, toCurrency string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(fromCurrency); err != nil {
+		violations = append(violations, fieldViolation("from_currency", err))
+	}
+
+	if err := val.ValidateCurrency(toCurrency); err != nil {
+		violations = append(violations, fieldViolation("to_currency", err))
+	}
+
+	if fromCurrency == toCurrency {
+		violations = append(violations, fieldViolation("to_currency", fmt.Errorf("must differ from from_currency")))
+	}
+
+	return violations
+}
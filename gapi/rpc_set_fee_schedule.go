@@ -0,0 +1,83 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetFeeSchedule(ctx context.Context, req *pb.SetFeeScheduleRequest) (*pb.SetFeeScheduleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetFeeScheduleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.UpsertFeeScheduleParams{
+		TransferType: req.GetTransferType(),
+		Currency:     req.GetCurrency(),
+		MinAmount:    req.GetMinAmount(),
+		FlatFee:      req.GetFlatFee(),
+		PercentBps:   req.GetPercentBps(),
+		MinFee:       req.GetMinFee(),
+		MaxFee:       req.GetMaxFee(),
+		UpdatedBy:    authPayload.Username,
+	}
+
+	schedule, err := server.store.UpsertFeeSchedule(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot set fee schedule: %v", err)
+	}
+
+	rsp := &pb.SetFeeScheduleResponse{
+		FeeSchedule: convertFeeSchedule(schedule),
+	}
+
+	return rsp, nil
+}
+
+func validateSetFeeScheduleRequest(req *pb.SetFeeScheduleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateFeeType(req.GetTransferType()); err != nil {
+		violations = append(violations, fieldViolation("transfer_type", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	fields := []struct {
+		name  string
+		value int64
+	}{
+		{"min_amount", req.GetMinAmount()},
+		{"flat_fee", req.GetFlatFee()},
+		{"percent_bps", req.GetPercentBps()},
+		{"min_fee", req.GetMinFee()},
+		{"max_fee", req.GetMaxFee()},
+	}
+	for _, field := range fields {
+		if field.value < 0 {
+			violations = append(violations, fieldViolation(field.name, fmt.Errorf("must not be negative")))
+		}
+	}
+
+	if req.GetPercentBps() > db.BasisPoints {
+		violations = append(violations, fieldViolation("percent_bps", fmt.Errorf("must be at most %d", db.BasisPoints)))
+	}
+
+	if req.GetMaxFee() > 0 && req.GetMaxFee() < req.GetMinFee() {
+		violations = append(violations, fieldViolation("max_fee", fmt.Errorf("must be 0 or at least min_fee")))
+	}
+
+	return violations
+}
@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) SetTransferLimit(ctx context.Context, req *pb.SetTransferLimitRequest) (*pb.SetTransferLimitResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateSetTransferLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.UpsertUserTransferLimitParams{
+		Username:       req.GetUsername(),
+		Currency:       req.GetCurrency(),
+		PerTransaction: req.GetPerTransaction(),
+		Daily:          req.GetDaily(),
+		Monthly:        req.GetMonthly(),
+		UpdatedBy:      authPayload.Username,
+	}
+
+	limit, err := server.store.UpsertUserTransferLimit(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			return nil, status.Errorf(codes.NotFound, "user [%s] not found", req.GetUsername())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot set transfer limit: %v", err)
+	}
+
+	rsp := &pb.SetTransferLimitResponse{
+		Username: limit.Username,
+		Limit: convertTransferLimits(db.TransferLimits{
+			PerTransaction: limit.PerTransaction,
+			Daily:          limit.Daily,
+			Monthly:        limit.Monthly,
+			Overridden:     true,
+		}, limit.Currency),
+		UpdatedBy: limit.UpdatedBy,
+		UpdatedAt: timestamppb.New(limit.UpdatedAt),
+	}
+
+	return rsp, nil
+}
+
+func validateSetTransferLimitRequest(req *pb.SetTransferLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateAmount(req.GetPerTransaction()); err != nil {
+		violations = append(violations, fieldViolation("per_transaction", err))
+	}
+
+	if err := val.ValidateAmount(req.GetDaily()); err != nil {
+		violations = append(violations, fieldViolation("daily", err))
+	} else if req.GetDaily() < req.GetPerTransaction() {
+		violations = append(violations, fieldViolation("daily", fmt.Errorf("must be at least per_transaction")))
+	}
+
+	if err := val.ValidateAmount(req.GetMonthly()); err != nil {
+		violations = append(violations, fieldViolation("monthly", err))
+	} else if req.GetMonthly() < req.GetDaily() {
+		violations = append(violations, fieldViolation("monthly", fmt.Errorf("must be at least daily")))
+	}
+
+	return violations
+}
@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/util"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateUpdateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduled, err := server.getAuthorizedScheduledTransfer(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduled.ID,
+		Amount: pgtype.Int8{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+		Schedule: pgtype.Text{
+			String: req.GetSchedule(),
+			Valid:  req.Schedule != nil,
+		},
+		InsufficientFundsPolicy: pgtype.Text{
+			String: req.GetInsufficientFundsPolicy(),
+			Valid:  req.InsufficientFundsPolicy != nil,
+		},
+		IsActive: pgtype.Bool{
+			Bool:  req.GetIsActive(),
+			Valid: req.IsActive != nil,
+		},
+	}
+
+	// a new schedule, or resuming a paused one, starts counting from now instead of running missed occurrences
+	resumed := req.IsActive != nil && req.GetIsActive() && !scheduled.IsActive
+	if req.Schedule != nil || resumed {
+		schedule := scheduled.Schedule
+		if req.Schedule != nil {
+			schedule = req.GetSchedule()
+		}
+
+		nextRunAt, err := util.NextRun(schedule, time.Now())
+		if err != nil {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("schedule", err)})
+		}
+
+		arg.NextRunAt = pgtype.Timestamptz{Time: nextRunAt, Valid: true}
+		arg.RetryCount = pgtype.Int4{Int32: 0, Valid: true}
+	}
+
+	scheduled, err = server.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "scheduled transfer [%d] not found", req.GetId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot update scheduled transfer: %v", err)
+	}
+
+	rsp := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduled),
+	}
+
+	return rsp, nil
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if req.Schedule != nil {
+		if err := val.ValidateSchedule(req.GetSchedule()); err != nil {
+			violations = append(violations, fieldViolation("schedule", err))
+		}
+	}
+
+	if req.InsufficientFundsPolicy != nil {
+		if err := val.ValidateInsufficientFundsPolicy(req.GetInsufficientFundsPolicy()); err != nil {
+			violations = append(violations, fieldViolation("insufficient_funds_policy", err))
+		}
+	}
+
+	return violations
+}
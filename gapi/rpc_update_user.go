@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/leedrum/simplebank/db/sqlc"
 	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/token"
 	"github.com/leedrum/simplebank/util"
 	"github.com/leedrum/simplebank/val"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -16,9 +17,9 @@ import (
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	authPayload, err := server.authorizeUser(ctx)
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
 	violations := validateUpdateUserRequest(req)
@@ -26,7 +27,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
+	if !authPayload.HasPermission(token.PermissionUsersAdmin) && authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other users")
 	}
 
@@ -67,6 +68,17 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "cannot update user: %v", err)
 	}
 
+	// a new password logs the user out everywhere, in case the old one leaked
+	if req.Password != nil {
+		if _, err := server.store.BlockUserSessions(ctx, user.Username); err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot revoke sessions: %v", err)
+		}
+
+		if err := server.revocations.RevokeUserTokens(ctx, user.Username, arg.PasswordChangedAt.Time); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
 	rsp := &pb.UpdateUserResponse{
 		User: convertUserToResponse(user),
 	}
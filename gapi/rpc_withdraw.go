@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+)
+
+func (server *Server) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCashOperationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.WithdrawTx(ctx, db.CashOperationTxParams{
+		AccountID: req.GetAccountId(),
+		Amount:    req.GetAmount(),
+		Reference: req.GetReference(),
+		Note:      req.GetNote(),
+		CreatedBy: authPayload.Username,
+	})
+	if err != nil {
+		return nil, cashOperationError(err, req, db.CashOperationWithdrawal)
+	}
+
+	rsp := &pb.WithdrawResponse{
+		Operation: convertCashOperation(result.Operation, result.Account.Currency),
+		Account:   convertAccount(result.Account),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,36 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAuthorizedScheduledTransfer loads a scheduled transfer that the caller is allowed to see.
+// Bankers can see every scheduled transfer, depositors only their own.
+func (server *Server) getAuthorizedScheduledTransfer(ctx context.Context, authPayload *token.Payload, id int64) (db.ScheduledTransfer, error) {
+	scheduled, err := server.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return scheduled, status.Errorf(codes.NotFound, "scheduled transfer [%d] not found", id)
+		}
+
+		return scheduled, status.Errorf(codes.Internal, "cannot get scheduled transfer: %v", err)
+	}
+
+	if !authPayload.HasPermission(token.PermissionTransfersAdmin) && scheduled.Owner != authPayload.Username {
+		err := fmt.Errorf("scheduled transfer [%d] does not belong to user [%s]", scheduled.ID, authPayload.Username)
+		return scheduled, permissionDeniedError("SCHEDULED_TRANSFER_NOT_OWNED", map[string]string{
+			"scheduled_transfer_id": strconv.FormatInt(scheduled.ID, 10),
+			"username":              authPayload.Username,
+		}, err)
+	}
+
+	return scheduled, nil
+}
@@ -2,9 +2,11 @@ package gapi
 
 import (
 	"fmt"
+	"net/http"
 
 	db "github.com/leedrum/simplebank/db/sqlc"
 	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/revocation"
 	"github.com/leedrum/simplebank/token"
 	"github.com/leedrum/simplebank/util"
 	"github.com/leedrum/simplebank/worker"
@@ -16,11 +18,25 @@ type Server struct {
 	store           db.Store
 	tokenMaker      token.Maker
 	taskDistributor worker.TaskDistributor
+	revocations     *revocation.List
 }
 
 // NewServer creates a new gRPC server
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	revocations *revocation.List,
+) (*Server, error) {
+	keyring, err := token.LoadKeyring(config.TokenKeyringFile, config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load token keyring: %w", err)
+	}
+
+	tokenMaker, err := token.NewMaker(config.TokenMaker, keyring, token.Claims{
+		Issuer:   config.TokenIssuer,
+		Audience: config.TokenAudience,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
@@ -30,7 +46,13 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 		store:           store,
 		tokenMaker:      tokenMaker,
 		taskDistributor: taskDistributor,
+		revocations:     revocations,
 	}
 
 	return server, err
 }
+
+// JWKSHandler serves the public keys that verify the tokens of the server
+func (server *Server) JWKSHandler() http.Handler {
+	return token.JWKSHandler(server.tokenMaker)
+}
@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"github.com/leedrum/simplebank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionOwner returns whose sessions a request is about: the authenticated user
+// when username is empty, anyone else only for bankers
+func sessionOwner(authPayload *token.Payload, username string) (string, error) {
+	if username == "" || username == authPayload.Username {
+		return authPayload.Username, nil
+	}
+
+	if !authPayload.HasPermission(token.PermissionUsersAdmin) {
+		return "", status.Errorf(codes.PermissionDenied, "cannot manage the sessions of other users")
+	}
+
+	return username, nil
+}
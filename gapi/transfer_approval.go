@@ -0,0 +1,73 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+	pb "github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reviewTransferRequest is implemented by both the approve and the reject request
+type reviewTransferRequest interface {
+	GetTransferApprovalId() int64
+	GetNote() string
+}
+
+func validateReviewTransferRequest(req reviewTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetTransferApprovalId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_approval_id", err))
+	}
+
+	if err := val.ValidateNote(req.GetNote()); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
+
+// transferApprovalError turns the errors of reviewing a transfer approval into gRPC status errors
+func transferApprovalError(err error, approvalID int64, action string) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "transfer approval [%d] not found", approvalID)
+	}
+
+	if limitErr := transferLimitError(err); limitErr != nil {
+		return limitErr
+	}
+
+	if notActiveErr := accountNotActiveError(err); notActiveErr != nil {
+		return notActiveErr
+	}
+
+	if errors.Is(err, db.ErrSelfReview) {
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+
+	violationType := ""
+	switch {
+	case errors.Is(err, db.ErrTransferApprovalNotPending):
+		violationType = "TRANSFER_APPROVAL_NOT_PENDING"
+	case errors.Is(err, db.ErrTransferApprovalExpired):
+		violationType = "TRANSFER_APPROVAL_EXPIRED"
+	case errors.Is(err, db.ErrInsufficientFunds):
+		violationType = "INSUFFICIENT_FUNDS"
+	default:
+		return status.Errorf(codes.Internal, "cannot %s transfer: %v", action, err)
+	}
+
+	return preconditionFailureError([]*errdetails.PreconditionFailure_Violation{{
+		Type:        violationType,
+		Subject:     fmt.Sprintf("transfer_approvals/%d", approvalID),
+		Description: err.Error(),
+	}})
+}
+
+var (
+	_ reviewTransferRequest = (*pb.ApproveTransferRequest)(nil)
+	_ reviewTransferRequest = (*pb.RejectTransferRequest)(nil)
+)
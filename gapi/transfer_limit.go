@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"errors"
+	"strconv"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+)
+
+// transferLimitError returns a ResourceExhausted error telling the remaining allowance if err is a TransferLimitError,
+// or nil otherwise
+func transferLimitError(err error) error {
+	var limitErr *db.TransferLimitError
+	if !errors.As(err, &limitErr) {
+		return nil
+	}
+
+	return resourceExhaustedError("TRANSFER_LIMIT_EXCEEDED", map[string]string{
+		"account_id": strconv.FormatInt(limitErr.AccountID, 10),
+		"currency":   limitErr.Currency,
+		"limit":      limitErr.Limit,
+		"remaining":  strconv.FormatInt(limitErr.Remaining, 10),
+	}, err)
+}
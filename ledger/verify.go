@@ -0,0 +1,85 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/leedrum/simplebank/db/sqlc"
+)
+
+// Report is the JSON result of a ledger consistency check
+type Report struct {
+	CheckedAt           time.Time            `json:"checked_at"`
+	Consistent          bool                 `json:"consistent"`
+	AccountsChecked     int64                `json:"accounts_checked"`
+	TransfersChecked    int64                `json:"transfers_checked"`
+	AccountDrift        []AccountDrift       `json:"account_drift"`
+	UnbalancedTransfers []UnbalancedTransfer `json:"unbalanced_transfers"`
+}
+
+// AccountDrift is an account whose stored balance differs from the sum of its entries
+type AccountDrift struct {
+	AccountID      int64  `json:"account_id"`
+	Owner          string `json:"owner"`
+	Currency       string `json:"currency"`
+	Balance        int64  `json:"balance"`
+	EntriesBalance int64  `json:"entries_balance"`
+	// Drift is Balance minus EntriesBalance
+	Drift int64 `json:"drift"`
+}
+
+// UnbalancedTransfer is a transfer that does not have exactly one debit of amount and one credit of to_amount.
+// For same-currency transfers the two amounts are equal, so the entries must sum to zero.
+type UnbalancedTransfer struct {
+	TransferID    int64 `json:"transfer_id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	ToAmount      int64 `json:"to_amount"`
+	EntryCount    int64 `json:"entry_count"`
+	EntriesSum    int64 `json:"entries_sum"`
+}
+
+// Verify recomputes every account balance from its entries and checks that every transfer is backed by
+// a matching pair of entries. It only reads, so it is safe to run against a live database.
+func Verify(ctx context.Context, store db.Querier) (Report, error) {
+	report := Report{
+		CheckedAt:           time.Now(),
+		AccountDrift:        []AccountDrift{},
+		UnbalancedTransfers: []UnbalancedTransfer{},
+	}
+
+	counts, err := store.CountLedgerRows(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to count ledger rows: %w", err)
+	}
+	report.AccountsChecked = counts.Accounts
+	report.TransfersChecked = counts.Transfers
+
+	drifts, err := store.ListAccountBalanceDrift(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to recompute account balances: %w", err)
+	}
+	for _, drift := range drifts {
+		report.AccountDrift = append(report.AccountDrift, AccountDrift{
+			AccountID:      drift.AccountID,
+			Owner:          drift.Owner,
+			Currency:       drift.Currency,
+			Balance:        drift.Balance,
+			EntriesBalance: drift.EntriesBalance,
+			Drift:          drift.Balance - drift.EntriesBalance,
+		})
+	}
+
+	transfers, err := store.ListUnbalancedTransfers(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to check transfer entries: %w", err)
+	}
+	for _, transfer := range transfers {
+		report.UnbalancedTransfers = append(report.UnbalancedTransfers, UnbalancedTransfer(transfer))
+	}
+
+	report.Consistent = len(report.AccountDrift) == 0 && len(report.UnbalancedTransfers) == 0
+	return report, nil
+}
@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, report Report, err error)
+	}{
+		{
+			name: "Consistent",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CountLedgerRows(gomock.Any()).
+					Times(1).
+					Return(db.CountLedgerRowsRow{Accounts: 3, Transfers: 5}, nil)
+				store.EXPECT().
+					ListAccountBalanceDrift(gomock.Any()).
+					Times(1).
+					Return([]db.ListAccountBalanceDriftRow{}, nil)
+				store.EXPECT().
+					ListUnbalancedTransfers(gomock.Any()).
+					Times(1).
+					Return([]db.ListUnbalancedTransfersRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, report Report, err error) {
+				require.NoError(t, err)
+				require.True(t, report.Consistent)
+				require.Equal(t, int64(3), report.AccountsChecked)
+				require.Equal(t, int64(5), report.TransfersChecked)
+				require.Empty(t, report.AccountDrift)
+				require.Empty(t, report.UnbalancedTransfers)
+			},
+		},
+		{
+			name: "Drift",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CountLedgerRows(gomock.Any()).
+					Times(1).
+					Return(db.CountLedgerRowsRow{Accounts: 3, Transfers: 5}, nil)
+				store.EXPECT().
+					ListAccountBalanceDrift(gomock.Any()).
+					Times(1).
+					Return([]db.ListAccountBalanceDriftRow{
+						{AccountID: 1, Owner: "alice", Currency: "USD", Balance: 150, EntriesBalance: 100},
+					}, nil)
+				store.EXPECT().
+					ListUnbalancedTransfers(gomock.Any()).
+					Times(1).
+					Return([]db.ListUnbalancedTransfersRow{
+						{TransferID: 4, FromAccountID: 1, ToAccountID: 2, Amount: 50, EntryCount: 1, EntriesSum: -50},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, report Report, err error) {
+				require.NoError(t, err)
+				require.False(t, report.Consistent)
+				require.Len(t, report.AccountDrift, 1)
+				require.Equal(t, int64(50), report.AccountDrift[0].Drift)
+				require.Len(t, report.UnbalancedTransfers, 1)
+				require.Equal(t, int64(4), report.UnbalancedTransfers[0].TransferID)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CountLedgerRows(gomock.Any()).
+					Times(1).
+					Return(db.CountLedgerRowsRow{}, nil)
+				store.EXPECT().
+					ListAccountBalanceDrift(gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+				store.EXPECT().
+					ListUnbalancedTransfers(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, report Report, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			report, err := Verify(context.Background(), store)
+			tc.checkResponse(t, report, err)
+		})
+	}
+}
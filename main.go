@@ -22,9 +22,12 @@ import (
 	"github.com/leedrum/simplebank/gapi"
 	"github.com/leedrum/simplebank/mail"
 	"github.com/leedrum/simplebank/pb"
+	"github.com/leedrum/simplebank/revocation"
+	"github.com/leedrum/simplebank/token"
 	"github.com/leedrum/simplebank/util"
 	"github.com/leedrum/simplebank/worker"
 	"github.com/rakyll/statik/fs"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -45,18 +48,46 @@ func main() {
 		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 
-	runDBMigration(config.MigrationURL, config.DBSource)
-
 	store := db.NewStore(conn)
 
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(config, store, os.Args[1:]))
+	}
+
+	runDBMigration(config.MigrationURL, config.DBSource)
+
 	redisOpts := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpts)
+	revocations := newRevocationList(config, store)
+
+	go runGatewayServer(config, store, taskDistributor, revocations)
+	go runTaskProcessor(redisOpts, store, config)
+	go runTaskScheduler(redisOpts, config)
+	runRPCServer(config, store, taskDistributor, revocations)
+}
+
+// newRevocationList creates the token revocation list shared by the servers,
+// kept in sync with the other instances through Redis when enabled
+func newRevocationList(config util.Config, store db.Store) *revocation.List {
+	if !config.TokenRevocationRedis {
+		return revocation.NewList(store, config.TokenRevocationCacheTTL, nil)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: config.RedisAddress,
+	})
+	revocations := revocation.NewList(store, config.TokenRevocationCacheTTL, redisClient)
+
+	go func() {
+		err := revocations.Listen(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("cannot listen to token revocations")
+		}
+	}()
 
-	go runGatewayServer(config, store, taskDistributor)
-	go runTaskProcessor(redisOpts, store)
-	runRPCServer(config, store, taskDistributor)
+	return revocations
 }
 
 func runDBMigration(migrationURL string, dbSource string) {
@@ -72,26 +103,43 @@ func runDBMigration(migrationURL string, dbSource string) {
 	log.Info().Msg("migration applied successfully")
 }
 
-func runTaskProcessor(redisOpts asynq.RedisClientOpt, store db.Store) {
+func runTaskProcessor(redisOpts asynq.RedisClientOpt, store db.Store, config util.Config) {
 	mailSender := mail.NewGmailSender(
 		"leedrum",
 		"developer.levantung@gmail.com",
 		"app_password",
 	)
-	processor := worker.NewRedisTaskProcessor(redisOpts, store, mailSender)
+	processor := worker.NewRedisTaskProcessor(redisOpts, store, mailSender, config)
 	err := processor.Start()
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot start task processor")
 	}
 }
 
-func runRPCServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runTaskScheduler(redisOpts asynq.RedisClientOpt, config util.Config) {
+	scheduler, err := worker.NewTaskScheduler(redisOpts, config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create task scheduler")
+	}
+
+	err = scheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot start task scheduler")
+	}
+}
+
+func runRPCServer(
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	revocations *revocation.List,
+) {
+	server, err := gapi.NewServer(config, store, taskDistributor, revocations)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	grpcLogger := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.AuthInterceptor)
 	grpcServer := grpc.NewServer(grpcLogger)
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	reflection.Register(grpcServer)
@@ -108,8 +156,13 @@ func runRPCServer(config util.Config, store db.Store, taskDistributor worker.Tas
 	}
 }
 
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	revocations *revocation.List,
+) {
+	server, err := gapi.NewServer(config, store, taskDistributor, revocations)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -123,7 +176,9 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	gprcMux := runtime.NewServeMux(jsonOptions)
+	headerMatcher := runtime.WithIncomingHeaderMatcher(gapi.IncomingHeaderMatcher)
+
+	gprcMux := runtime.NewServeMux(jsonOptions, headerMatcher)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -135,6 +190,7 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 
 	mux := http.NewServeMux()
 	mux.Handle("/", gprcMux)
+	mux.Handle(token.JWKSPath, server.JWKSHandler())
 
 	statikFS, err := fs.New()
 	if err != nil {
@@ -157,8 +213,8 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 	}
 }
 
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runGinServer(config util.Config, store db.Store, revocations *revocation.List) {
+	server, err := api.NewServer(config, store, revocations)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -0,0 +1,66 @@
+package money
+
+import (
+	"fmt"
+)
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	// Code is the three-letter alphabetic code, e.g. USD
+	Code string
+	// Number is the three-digit numeric code
+	Number int
+	// Exponent is the number of decimal digits of the minor unit, e.g. 2 for cents and 0 for VND
+	Exponent int
+}
+
+// currencies is the subset of ISO 4217 the bank knows how to format
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Number: 784, Exponent: 2},
+	"AUD": {Code: "AUD", Number: 36, Exponent: 2},
+	"BHD": {Code: "BHD", Number: 48, Exponent: 3},
+	"BRL": {Code: "BRL", Number: 986, Exponent: 2},
+	"CAD": {Code: "CAD", Number: 124, Exponent: 2},
+	"CHF": {Code: "CHF", Number: 756, Exponent: 2},
+	"CLP": {Code: "CLP", Number: 152, Exponent: 0},
+	"CNY": {Code: "CNY", Number: 156, Exponent: 2},
+	"CZK": {Code: "CZK", Number: 203, Exponent: 2},
+	"DKK": {Code: "DKK", Number: 208, Exponent: 2},
+	"EUR": {Code: "EUR", Number: 978, Exponent: 2},
+	"GBP": {Code: "GBP", Number: 826, Exponent: 2},
+	"HKD": {Code: "HKD", Number: 344, Exponent: 2},
+	"HUF": {Code: "HUF", Number: 348, Exponent: 2},
+	"IDR": {Code: "IDR", Number: 360, Exponent: 2},
+	"INR": {Code: "INR", Number: 356, Exponent: 2},
+	"ISK": {Code: "ISK", Number: 352, Exponent: 0},
+	"JOD": {Code: "JOD", Number: 400, Exponent: 3},
+	"JPY": {Code: "JPY", Number: 392, Exponent: 0},
+	"KRW": {Code: "KRW", Number: 410, Exponent: 0},
+	"KWD": {Code: "KWD", Number: 414, Exponent: 3},
+	"MXN": {Code: "MXN", Number: 484, Exponent: 2},
+	"MYR": {Code: "MYR", Number: 458, Exponent: 2},
+	"NOK": {Code: "NOK", Number: 578, Exponent: 2},
+	"NZD": {Code: "NZD", Number: 554, Exponent: 2},
+	"OMR": {Code: "OMR", Number: 512, Exponent: 3},
+	"PHP": {Code: "PHP", Number: 608, Exponent: 2},
+	"PLN": {Code: "PLN", Number: 985, Exponent: 2},
+	"SEK": {Code: "SEK", Number: 752, Exponent: 2},
+	"SGD": {Code: "SGD", Number: 702, Exponent: 2},
+	"THB": {Code: "THB", Number: 764, Exponent: 2},
+	"TND": {Code: "TND", Number: 788, Exponent: 3},
+	"TRY": {Code: "TRY", Number: 949, Exponent: 2},
+	"TWD": {Code: "TWD", Number: 901, Exponent: 2},
+	"USD": {Code: "USD", Number: 840, Exponent: 2},
+	"VND": {Code: "VND", Number: 704, Exponent: 0},
+	"ZAR": {Code: "ZAR", Number: 710, Exponent: 2},
+}
+
+// LookupCurrency returns the ISO 4217 details of a currency code
+func LookupCurrency(code string) (Currency, error) {
+	currency, ok := currencies[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,206 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrOverflow         = errors.New("amount out of range")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// RateScale is the fixed-point scale of exchange rates, so a rate equal to it converts one to one
+const RateScale int64 = 100_000_000
+
+// Money is an amount in the minor unit of its currency, e.g. cents for USD and dong for VND.
+// This is how amounts are stored in the database.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// New creates money from an amount in minor units
+func New(amount int64, currency string) (Money, error) {
+	if _, err := LookupCurrency(currency); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Parse reads a decimal string such as "12.34" or "-5" in the given currency.
+// It accepts at most as many decimals as the currency's minor unit has and never rounds.
+func Parse(value string, currency string) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	digits, negative := strings.CutPrefix(value, "-")
+	whole, fraction, hasPoint := strings.Cut(digits, ".")
+	if !isDigits(whole) || (hasPoint && !isDigits(fraction)) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, value)
+	}
+
+	if len(fraction) > c.Exponent {
+		return Money{}, fmt.Errorf("%w: %s has %d decimals, got %q", ErrInvalidAmount, currency, c.Exponent, value)
+	}
+
+	minor := whole + fraction + strings.Repeat("0", c.Exponent-len(fraction))
+	if negative {
+		minor = "-" + minor
+	}
+
+	amount, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, value)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Format renders an amount in minor units as a decimal string with exactly the currency's number of decimals.
+// Unknown currencies are rendered without decimals.
+func Format(amount int64, currency string) string {
+	exponent := 0
+	if c, err := LookupCurrency(currency); err == nil {
+		exponent = c.Exponent
+	}
+
+	// work on the absolute value as uint64 so math.MinInt64 does not overflow
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-(amount + 1)) + 1
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	point := len(digits) - exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// String renders the money as a decimal string followed by its currency code, e.g. "12.34 USD"
+func (m Money) String() string {
+	return Format(m.Amount, m.Currency) + " " + m.Currency
+}
+
+// Decimal renders the amount as a decimal string without the currency code
+func (m Money) Decimal() string {
+	return Format(m.Amount, m.Currency)
+}
+
+// Add returns m + other; both must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	amount, err := Add(m.Amount, other.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - other; both must be in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	amount, err := Sub(m.Amount, other.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Neg returns -m
+func (m Money) Neg() (Money, error) {
+	amount, err := Sub(0, m.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Add returns a + b, or ErrOverflow if the result does not fit in an int64
+func Add(a int64, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+
+	return a + b, nil
+}
+
+// Sub returns a - b, or ErrOverflow if the result does not fit in an int64
+func Sub(a int64, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+
+	return a - b, nil
+}
+
+// Convert changes m into toCurrency at rate, which is the amount of toCurrency for one major unit of
+// m's currency scaled by RateScale. Differences in minor-unit exponents are accounted for, and the
+// result is truncated toward zero.
+func Convert(m Money, toCurrency string, rate int64) (Money, error) {
+	from, err := LookupCurrency(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	to, err := LookupCurrency(toCurrency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(rate))
+	numerator.Mul(numerator, pow10(to.Exponent))
+	denominator := new(big.Int).Mul(big.NewInt(RateScale), pow10(from.Exponent))
+
+	converted := numerator.Quo(numerator, denominator)
+	if !converted.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s at rate %d", ErrOverflow, m, rate)
+	}
+
+	return Money{Amount: converted.Int64(), Currency: toCurrency}, nil
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,144 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	for _, code := range []string{util.USD, util.EUR, util.CAD, util.VND} {
+		currency, err := LookupCurrency(code)
+		require.NoError(t, err)
+		require.Equal(t, code, currency.Code)
+	}
+
+	vnd, err := LookupCurrency(util.VND)
+	require.NoError(t, err)
+	require.Zero(t, vnd.Exponent)
+
+	_, err = LookupCurrency("XYZ")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency string
+		amount   int64
+		err      error
+	}{
+		{value: "12.34", currency: util.USD, amount: 1234},
+		{value: "12.3", currency: util.USD, amount: 1230},
+		{value: "12", currency: util.USD, amount: 1200},
+		{value: "0.05", currency: util.EUR, amount: 5},
+		{value: "-7.50", currency: util.CAD, amount: -750},
+		{value: "25000", currency: util.VND, amount: 25000},
+		{value: "1.234", currency: "KWD", amount: 1234},
+		{value: "92233720368547758.07", currency: util.USD, amount: math.MaxInt64},
+		{value: "-92233720368547758.08", currency: util.USD, amount: math.MinInt64},
+		{value: "92233720368547758.08", currency: util.USD, err: ErrOverflow},
+		{value: "12.345", currency: util.USD, err: ErrInvalidAmount},
+		{value: "25000.5", currency: util.VND, err: ErrInvalidAmount},
+		{value: "25000.", currency: util.VND, err: ErrInvalidAmount},
+		{value: ".5", currency: util.USD, err: ErrInvalidAmount},
+		{value: "+1", currency: util.USD, err: ErrInvalidAmount},
+		{value: "1,000", currency: util.USD, err: ErrInvalidAmount},
+		{value: "1e3", currency: util.USD, err: ErrInvalidAmount},
+		{value: "", currency: util.USD, err: ErrInvalidAmount},
+		{value: "1", currency: "XYZ", err: ErrUnknownCurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value+" "+tc.currency, func(t *testing.T) {
+			m, err := Parse(tc.value, tc.currency)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.amount, m.Amount)
+			require.Equal(t, tc.currency, m.Currency)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "12.34", Format(1234, util.USD))
+	require.Equal(t, "0.05", Format(5, util.USD))
+	require.Equal(t, "0.00", Format(0, util.EUR))
+	require.Equal(t, "-7.50", Format(-750, util.CAD))
+	require.Equal(t, "25000", Format(25000, util.VND))
+	require.Equal(t, "1.234", Format(1234, "KWD"))
+	require.Equal(t, "-92233720368547758.08", Format(math.MinInt64, util.USD))
+	require.Equal(t, "42", Format(42, "XYZ"))
+
+	m, err := New(1999, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, "19.99 USD", m.String())
+	require.Equal(t, "19.99", m.Decimal())
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	for _, currency := range []string{util.USD, util.EUR, util.CAD, util.VND} {
+		amount := util.RandomInt(-1_000_000, 1_000_000)
+		m, err := Parse(Format(amount, currency), currency)
+		require.NoError(t, err)
+		require.Equal(t, amount, m.Amount)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := Money{Amount: 150, Currency: util.USD}
+	b := Money{Amount: 50, Currency: util.USD}
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), sum.Amount)
+
+	diff, err := b.Sub(a)
+	require.NoError(t, err)
+	require.Equal(t, int64(-100), diff.Amount)
+
+	neg, err := a.Neg()
+	require.NoError(t, err)
+	require.Equal(t, int64(-150), neg.Amount)
+
+	_, err = a.Add(Money{Amount: 1, Currency: util.EUR})
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = Add(math.MaxInt64, 1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Add(math.MinInt64, -1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Sub(math.MinInt64, 1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Money{Amount: math.MinInt64, Currency: util.USD}.Neg()
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestConvert(t *testing.T) {
+	// 1 USD = 0.9 EUR
+	eur, err := Convert(Money{Amount: 10000, Currency: util.USD}, util.EUR, RateScale*9/10)
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 9000, Currency: util.EUR}, eur)
+
+	// 1 USD = 25000 VND: 1.00 USD has 100 minor units but 1 VND is a whole dong
+	vnd, err := Convert(Money{Amount: 100, Currency: util.USD}, util.VND, RateScale*25000)
+	require.NoError(t, err)
+	require.Equal(t, int64(25000), vnd.Amount)
+
+	// 25000 VND = 1 USD, truncated toward zero
+	usd, err := Convert(Money{Amount: 25999, Currency: util.VND}, util.USD, RateScale/25000)
+	require.NoError(t, err)
+	require.Equal(t, int64(103), usd.Amount)
+
+	_, err = Convert(Money{Amount: math.MaxInt64, Currency: util.USD}, util.EUR, RateScale*2)
+	require.ErrorIs(t, err, ErrOverflow)
+}
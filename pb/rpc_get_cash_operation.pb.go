@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.20.3
+// source: rpc_get_cash_operation.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCashOperationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reference string `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *GetCashOperationRequest) Reset() {
+	*x = GetCashOperationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_cash_operation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCashOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCashOperationRequest) ProtoMessage() {}
+
+func (x *GetCashOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_cash_operation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCashOperationRequest.ProtoReflect.Descriptor instead.
+func (*GetCashOperationRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_cash_operation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCashOperationRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type GetCashOperationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation *CashOperation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+}
+
+func (x *GetCashOperationResponse) Reset() {
+	*x = GetCashOperationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_cash_operation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCashOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCashOperationResponse) ProtoMessage() {}
+
+func (x *GetCashOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_cash_operation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCashOperationResponse.ProtoReflect.Descriptor instead.
+func (*GetCashOperationResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_cash_operation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCashOperationResponse) GetOperation() *CashOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+var File_rpc_get_cash_operation_proto protoreflect.FileDescriptor
+
+var file_rpc_get_cash_operation_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x61, 0x73, 0x68, 0x5f, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x1a, 0x14, 0x63, 0x61, 0x73, 0x68, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x37, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x73, 0x68, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x4b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x61, 0x73, 0x68, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x73, 0x68, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x22,
+	0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x65,
+	0x64, 0x72, 0x75, 0x6d, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_cash_operation_proto_rawDescOnce sync.Once
+	file_rpc_get_cash_operation_proto_rawDescData = file_rpc_get_cash_operation_proto_rawDesc
+)
+
+func file_rpc_get_cash_operation_proto_rawDescGZIP() []byte {
+	file_rpc_get_cash_operation_proto_rawDescOnce.Do(func() {
+		file_rpc_get_cash_operation_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_cash_operation_proto_rawDescData)
+	})
+	return file_rpc_get_cash_operation_proto_rawDescData
+}
+
+var file_rpc_get_cash_operation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_cash_operation_proto_goTypes = []any{
+	(*GetCashOperationRequest)(nil),  // 0: pb.GetCashOperationRequest
+	(*GetCashOperationResponse)(nil), // 1: pb.GetCashOperationResponse
+	(*CashOperation)(nil),            // 2: pb.CashOperation
+}
+var file_rpc_get_cash_operation_proto_depIdxs = []int32{
+	2, // 0: pb.GetCashOperationResponse.operation:type_name -> pb.CashOperation
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_cash_operation_proto_init() }
+func file_rpc_get_cash_operation_proto_init() {
+	if File_rpc_get_cash_operation_proto != nil {
+		return
+	}
+	file_cash_operation_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_cash_operation_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCashOperationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_cash_operation_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCashOperationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_cash_operation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_cash_operation_proto_goTypes,
+		DependencyIndexes: file_rpc_get_cash_operation_proto_depIdxs,
+		MessageInfos:      file_rpc_get_cash_operation_proto_msgTypes,
+	}.Build()
+	File_rpc_get_cash_operation_proto = out.File
+	file_rpc_get_cash_operation_proto_rawDesc = nil
+	file_rpc_get_cash_operation_proto_goTypes = nil
+	file_rpc_get_cash_operation_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// Channel is the Redis channel on which the revocations are broadcast to the other instances
+const Channel = "token_revocations"
+
+// event is a revocation broadcast on Channel, either of a single token or of the tokens of a user
+type event struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Username  string    `json:"username"`
+	NotBefore time.Time `json:"not_before"`
+}
+
+// List tells if a token was revoked before it expired. Revocations are stored in Postgres
+// and cached in memory: a revocation is kept until the token expires, while a token found valid
+// is only trusted for cacheTTL, after which another instance may have revoked it.
+// With a Redis client, revocations are broadcast so the other instances apply them right away.
+type List struct {
+	store    db.Querier
+	cacheTTL time.Duration
+	redis    *redis.Client
+
+	mu sync.Mutex
+	// revoked holds the revoked token IDs until the tokens expire
+	revoked map[uuid.UUID]time.Time
+	// cutoffs holds the time before which the tokens of a user are rejected
+	cutoffs map[string]time.Time
+	// checked holds the token IDs found valid until they must be looked up again
+	checked map[uuid.UUID]time.Time
+	sweptAt time.Time
+}
+
+// NewList creates a revocation list backed by store, redisClient is optional
+func NewList(store db.Querier, cacheTTL time.Duration, redisClient *redis.Client) *List {
+	return &List{
+		store:    store,
+		cacheTTL: cacheTTL,
+		redis:    redisClient,
+		revoked:  make(map[uuid.UUID]time.Time),
+		cutoffs:  make(map[string]time.Time),
+		checked:  make(map[uuid.UUID]time.Time),
+	}
+}
+
+// IsRevoked tells if the token was revoked, by its ID or by a cutoff of its user
+func (list *List) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	now := time.Now()
+
+	list.mu.Lock()
+	revoked, cached := list.cached(payload, now)
+	list.mu.Unlock()
+	if cached {
+		return revoked, nil
+	}
+
+	row, err := list.store.GetTokenRevocation(ctx, db.GetTokenRevocationParams{
+		TokenID:  payload.ID,
+		Username: payload.Username,
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot look up token revocation: %w", err)
+	}
+
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	list.sweep(now)
+	if row.Revoked {
+		list.revoked[payload.ID] = payload.ExpiredAt
+	}
+	list.setCutoff(payload.Username, row.NotBefore)
+
+	revoked, _ = list.cached(payload, now)
+	if !revoked {
+		list.checked[payload.ID] = now.Add(list.cacheTTL)
+	}
+
+	return revoked, nil
+}
+
+// RevokeSessionTokens revokes the access tokens issued with the session and the ones rotated with it
+func (list *List) RevokeSessionTokens(ctx context.Context, sessionID uuid.UUID) error {
+	tokens, err := list.store.RevokeSessionTokens(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("cannot revoke session tokens: %w", err)
+	}
+
+	for _, revokedToken := range tokens {
+		list.publish(ctx, event{TokenID: revokedToken.ID, ExpiresAt: revokedToken.ExpiresAt})
+	}
+
+	return nil
+}
+
+// RevokeUserTokens revokes every token of the user issued before notBefore.
+// The cutoff is rounded up to the second, as JWT issue times are in whole seconds:
+// the tokens issued within the same second are revoked too, even the ones issued just after notBefore.
+func (list *List) RevokeUserTokens(ctx context.Context, username string, notBefore time.Time) error {
+	second := notBefore.Truncate(time.Second)
+	if second.Before(notBefore) {
+		second = second.Add(time.Second)
+	}
+
+	cutoff, err := list.store.SetTokenNotBefore(ctx, db.SetTokenNotBeforeParams{
+		Username:  username,
+		NotBefore: second,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot revoke user tokens: %w", err)
+	}
+
+	list.publish(ctx, event{Username: cutoff.Username, NotBefore: cutoff.NotBefore})
+	return nil
+}
+
+// Listen applies the revocations broadcast by the other instances until ctx is done.
+// It returns right away when the list has no Redis client.
+func (list *List) Listen(ctx context.Context) error {
+	if list.redis == nil {
+		return nil
+	}
+
+	pubsub := list.redis.Subscribe(ctx, Channel)
+	defer pubsub.Close()
+
+	// wait for the subscription, so a Redis that cannot be reached is reported
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("cannot subscribe to %s: %w", Channel, err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var e event
+			if err := json.Unmarshal([]byte(message.Payload), &e); err != nil {
+				log.Error().Err(err).Str("channel", Channel).Msg("cannot decode token revocation")
+				continue
+			}
+			list.apply(e)
+		}
+	}
+}
+
+// publish applies a revocation to the local cache, then broadcasts it to the other instances.
+// A failed broadcast is only logged: the revocation is stored, so they find it once their cache expires.
+func (list *List) publish(ctx context.Context, e event) {
+	list.apply(e)
+
+	if list.redis == nil {
+		return
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot encode token revocation")
+		return
+	}
+
+	if err := list.redis.Publish(ctx, Channel, data).Err(); err != nil {
+		log.Error().Err(err).Str("channel", Channel).Msg("cannot broadcast token revocation")
+	}
+}
+
+func (list *List) apply(e event) {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	if e.TokenID != uuid.Nil {
+		list.revoked[e.TokenID] = e.ExpiresAt
+		delete(list.checked, e.TokenID)
+	}
+
+	if e.Username != "" {
+		list.setCutoff(e.Username, e.NotBefore)
+	}
+}
+
+// cached returns the revocation of the token when the cache can tell it, the lock must be held
+func (list *List) cached(payload *token.Payload, now time.Time) (revoked bool, ok bool) {
+	if _, ok := list.revoked[payload.ID]; ok {
+		return true, true
+	}
+
+	if notBefore, ok := list.cutoffs[payload.Username]; ok && payload.IssuedAt.Before(notBefore) {
+		return true, true
+	}
+
+	if checkedUntil, ok := list.checked[payload.ID]; ok && now.Before(checkedUntil) {
+		return false, true
+	}
+
+	return false, false
+}
+
+// setCutoff only moves the cutoff of a user forward, like the query storing it
+func (list *List) setCutoff(username string, notBefore time.Time) {
+	if notBefore.After(list.cutoffs[username]) {
+		list.cutoffs[username] = notBefore
+	}
+}
+
+// sweep drops the expired entries, at most once per cacheTTL, the lock must be held
+func (list *List) sweep(now time.Time) {
+	if now.Sub(list.sweptAt) < list.cacheTTL {
+		return
+	}
+	list.sweptAt = now
+
+	for tokenID, expiresAt := range list.revoked {
+		if now.After(expiresAt) {
+			delete(list.revoked, tokenID)
+		}
+	}
+
+	for tokenID, checkedUntil := range list.checked {
+		if !now.Before(checkedUntil) {
+			delete(list.checked, tokenID)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/leedrum/simplebank/db/mock"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomPayload(t *testing.T) *token.Payload {
+	payload, err := token.NewPayload(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestIsRevokedCachesValidTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+	payload := randomPayload(t)
+
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Eq(db.GetTokenRevocationParams{
+			TokenID:  payload.ID,
+			Username: payload.Username,
+		})).
+		Times(1).
+		Return(db.GetTokenRevocationRow{NotBefore: time.Unix(0, 0)}, nil)
+
+	for i := 0; i < 3; i++ {
+		revoked, err := list.IsRevoked(context.Background(), payload)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	}
+}
+
+func TestIsRevokedLooksUpAgainAfterCacheTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, 0, nil)
+	payload := randomPayload(t)
+
+	// another instance revokes the token between the lookups
+	gomock.InOrder(
+		store.EXPECT().
+			GetTokenRevocation(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.GetTokenRevocationRow{}, nil),
+		store.EXPECT().
+			GetTokenRevocation(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.GetTokenRevocationRow{Revoked: true}, nil),
+	)
+
+	revoked, err := list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	revoked, err = list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// a revocation is kept until the token expires
+	revoked, err = list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestIsRevokedByCutoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+	payload := randomPayload(t)
+
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetTokenRevocationRow{NotBefore: payload.IssuedAt.Add(time.Second)}, nil)
+
+	revoked, err := list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// the cutoff applies to the other tokens of the user issued before it, without a lookup
+	older := randomPayload(t)
+	older.Username = payload.Username
+	revoked, err = list.IsRevoked(context.Background(), older)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestIsRevokedLookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetTokenRevocationRow{}, sql.ErrConnDone)
+
+	revoked, err := list.IsRevoked(context.Background(), randomPayload(t))
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.False(t, revoked)
+}
+
+func TestRevokeSessionTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+	payload := randomPayload(t)
+	sessionID := uuid.New()
+
+	// the token was found valid and cached before being revoked
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetTokenRevocationRow{}, nil)
+
+	store.EXPECT().
+		RevokeSessionTokens(gomock.Any(), gomock.Eq(sessionID)).
+		Times(1).
+		Return([]db.RevokedToken{
+			{ID: payload.ID, Username: payload.Username, ExpiresAt: payload.ExpiredAt},
+		}, nil)
+
+	revoked, err := list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = list.RevokeSessionTokens(context.Background(), sessionID)
+	require.NoError(t, err)
+
+	revoked, err = list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+	notBefore := time.Now().Truncate(time.Second)
+	payload := randomPayload(t)
+	payload.IssuedAt = notBefore.Add(-time.Second)
+
+	store.EXPECT().
+		SetTokenNotBefore(gomock.Any(), gomock.Eq(db.SetTokenNotBeforeParams{
+			Username:  payload.Username,
+			NotBefore: notBefore,
+		})).
+		Times(1).
+		Return(db.TokenCutoff{Username: payload.Username, NotBefore: notBefore}, nil)
+
+	err := list.RevokeUserTokens(context.Background(), payload.Username, notBefore)
+	require.NoError(t, err)
+
+	revoked, err := list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// tokens issued after the cutoff are looked up as usual
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetTokenRevocationRow{NotBefore: notBefore}, nil)
+
+	newer := randomPayload(t)
+	newer.Username = payload.Username
+	revoked, err = list.IsRevoked(context.Background(), newer)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestRevokeUserTokensSecondPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	list := NewList(store, time.Minute, nil)
+	second := time.Now().Truncate(time.Second)
+	nextSecond := second.Add(time.Second)
+
+	store.EXPECT().
+		SetTokenNotBefore(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SetTokenNotBeforeParams) (db.TokenCutoff, error) {
+			require.Equal(t, nextSecond, arg.NotBefore)
+			return db.TokenCutoff{Username: arg.Username, NotBefore: arg.NotBefore}, nil
+		})
+
+	payload := randomPayload(t)
+	err := list.RevokeUserTokens(context.Background(), payload.Username, second.Add(500*time.Millisecond))
+	require.NoError(t, err)
+
+	store.EXPECT().
+		GetTokenRevocation(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GetTokenRevocationRow{NotBefore: nextSecond}, nil)
+
+	// a JWT issued earlier in the same second tells the start of the second, and is revoked
+	payload.IssuedAt = second
+	revoked, err := list.IsRevoked(context.Background(), payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	newer := randomPayload(t)
+	newer.Username = payload.Username
+	newer.IssuedAt = nextSecond
+	revoked, err = list.IsRevoked(context.Background(), newer)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
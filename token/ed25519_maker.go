@@ -0,0 +1,211 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Formats of the tokens signed by an Ed25519Maker
+const (
+	FormatPaseto = "paseto"
+	FormatJWT    = "jwt"
+)
+
+// ErrVerifierOnly is returned when creating a token with a maker that only holds public keys
+var ErrVerifierOnly = errors.New("maker can only verify tokens")
+
+// Ed25519Maker signs tokens with the Ed25519 keys of a keyring, as PASETO v4.public or EdDSA JWT tokens,
+// so other services can verify them with the published public keys instead of a shared secret
+type Ed25519Maker struct {
+	format  string
+	keyring *Keyring
+	claims  Claims
+	// publicKeys verify the tokens of a verifier-only maker, which has no keyring
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// NewEd25519Maker creates a maker that signs with the active key of keyring in format
+// and verifies tokens of both formats with any of its keys that is not retired
+func NewEd25519Maker(format string, keyring *Keyring, claims Claims) (Maker, error) {
+	if format != FormatPaseto && format != FormatJWT {
+		return nil, fmt.Errorf("unsupported token format %q", format)
+	}
+
+	// only the active key needs a key pair, so a keyring of secrets can move to Ed25519 by adding a key
+	key, err := keyring.ActiveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("key %q: invalid private key size: must be %d bytes", key.ID, ed25519.PrivateKeySize)
+	}
+
+	return &Ed25519Maker{format: format, keyring: keyring, claims: claims}, nil
+}
+
+// NewVerifierFromJWKS creates a maker that verifies the tokens signed with the keys of a JWKS document,
+// as published by JWKSHandler, and issued with claims. It cannot create tokens.
+func NewVerifierFromJWKS(document []byte, claims Claims) (Maker, error) {
+	var jwks JWKS
+	if err := json.Unmarshal(document, &jwks); err != nil {
+		return nil, fmt.Errorf("cannot parse JWKS: %w", err)
+	}
+
+	publicKeys, err := jwks.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ed25519Maker{publicKeys: publicKeys, claims: claims}, nil
+}
+
+// CreateToken signs a new token for a specific username and duration with the active key
+func (maker *Ed25519Maker) CreateToken(username string, role string, permissions []string, duration time.Duration) (string, *Payload, error) {
+	if maker.keyring == nil {
+		return "", nil, ErrVerifierOnly
+	}
+
+	payload, err := maker.claims.newPayload(username, role, permissions, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	key, err := maker.keyring.ActiveKey()
+	if err != nil {
+		return "", payload, err
+	}
+
+	if maker.format == FormatJWT {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, newJWTClaims(payload))
+		jwtToken.Header["kid"] = key.ID
+		token, err := jwtToken.SignedString(key.PrivateKey)
+		return token, payload, err
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+
+	footer, err := json.Marshal(pasetoFooter{KeyID: key.ID})
+	if err != nil {
+		return "", payload, err
+	}
+
+	return signPasetoV4(key.PrivateKey, message, footer), payload, nil
+}
+
+// VerifyToken check if the token is valid or not, PASETO v4.public and EdDSA JWT tokens are both accepted
+func (maker *Ed25519Maker) VerifyToken(token string) (*Payload, error) {
+	if strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return maker.verifyPaseto(token)
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		return maker.publicKey(keyID)
+	},
+		jwt.WithoutClaimsValidation(),
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwtToken.Claims.(*jwtClaims)
+	if !ok {
+		return nil, jwt.ErrTokenMalformed
+	}
+
+	payload, err := claims.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := payload.Validate(maker.claims); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func (maker *Ed25519Maker) verifyPaseto(token string) (*Payload, error) {
+	message, signature, footerData, err := parsePasetoV4(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var footer pasetoFooter
+	if err := json.Unmarshal(footerData, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, err := maker.publicKey(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyPasetoV4(publicKey, message, signature, footerData); err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(message, payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Validate(maker.claims); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// publicKey returns the public key of the key with the given ID
+func (maker *Ed25519Maker) publicKey(keyID string) (ed25519.PublicKey, error) {
+	if maker.keyring == nil {
+		publicKey, ok := maker.publicKeys[keyID]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		return publicKey, nil
+	}
+
+	key, err := maker.keyring.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, ErrUnknownKey
+	}
+
+	return key.PrivateKey.Public().(ed25519.PublicKey), nil
+}
+
+// JWKS returns the public keys that verify the tokens of the maker
+func (maker *Ed25519Maker) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if maker.keyring == nil {
+		for keyID, publicKey := range maker.publicKeys {
+			jwks.Keys = append(jwks.Keys, newJWK(keyID, publicKey))
+		}
+		return jwks
+	}
+
+	for _, key := range maker.keyring.Keys {
+		if key.Retired() || len(key.PrivateKey) != ed25519.PrivateKeySize {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, newJWK(key.ID, key.PrivateKey.Public().(ed25519.PublicKey)))
+	}
+
+	return jwks
+}
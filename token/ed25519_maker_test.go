@@ -0,0 +1,141 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Keyring(t *testing.T, keyID string) *Keyring {
+	keyring := &Keyring{}
+	_, err := keyring.AddKey(keyID)
+	require.NoError(t, err)
+
+	return keyring
+}
+
+func TestEd25519Maker(t *testing.T) {
+	for _, format := range []string{FormatPaseto, FormatJWT} {
+		t.Run(format, func(t *testing.T) {
+			maker, err := NewEd25519Maker(format, NewKeyring(util.RandomString(32)), Claims{})
+			require.Error(t, err)
+			require.Nil(t, maker)
+
+			maker, err = NewEd25519Maker(format, newEd25519Keyring(t, "k1"), Claims{})
+			require.NoError(t, err)
+
+			username := util.RandomOwner()
+			duration := time.Minute
+			issuedAt := time.Now()
+
+			token, payload, err := maker.CreateToken(username, util.DepositorRole, nil, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.Equal(t, format == FormatPaseto, strings.HasPrefix(token, pasetoV4PublicHeader))
+
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, util.DepositorRole, payload.Role)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, issuedAt.Add(duration), payload.ExpiredAt, time.Second)
+
+			// a maker with other keys rejects the token
+			otherMaker, err := NewEd25519Maker(format, newEd25519Keyring(t, "k1"), Claims{})
+			require.NoError(t, err)
+			_, err = otherMaker.VerifyToken(token)
+			require.Error(t, err)
+
+			token, _, err = maker.CreateToken(username, util.DepositorRole, nil, -time.Minute)
+			require.NoError(t, err)
+			_, err = maker.VerifyToken(token)
+			require.ErrorIs(t, err, ErrExpiredToken)
+		})
+	}
+}
+
+func TestEd25519MakerRejectsSymmetricJWT(t *testing.T) {
+	keyring := newEd25519Keyring(t, "k1")
+	maker, err := NewEd25519Maker(FormatJWT, keyring, Claims{})
+	require.NoError(t, err)
+
+	// an HS256 token signed with the public key must not pass as EdDSA
+	publicKey := keyring.Keys[0].PrivateKey.Public()
+	payload, err := NewPayload(util.RandomOwner(), util.BankerRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload))
+	jwtToken.Header["kid"] = "k1"
+	token, err := jwtToken.SignedString([]byte(publicKey.(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token)
+	require.Error(t, err)
+}
+
+func TestVerifierFromJWKS(t *testing.T) {
+	keyring := newEd25519Keyring(t, "k1")
+	maker, err := NewEd25519Maker(FormatPaseto, keyring, Claims{})
+	require.NoError(t, err)
+
+	pasetoToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	jwtMaker, err := NewEd25519Maker(FormatJWT, keyring, Claims{})
+	require.NoError(t, err)
+
+	jwtToken, _, err := jwtMaker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, JWKSPath, nil)
+	JWKSHandler(maker).ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var jwks JWKS
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, "k1", jwks.Keys[0].KeyID)
+	require.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+
+	verifier, err := NewVerifierFromJWKS(recorder.Body.Bytes(), Claims{})
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(pasetoToken)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(jwtToken)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+	require.ErrorIs(t, err, ErrVerifierOnly)
+
+	// tokens of a key added after the document was fetched are not known to the verifier
+	_, err = keyring.AddKey("k2")
+	require.NoError(t, err)
+
+	newToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(newToken)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestJWKSHandlerSymmetricMaker(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, JWKSPath, nil)
+	JWKSHandler(maker).ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"keys":[]}`, recorder.Body.String())
+}
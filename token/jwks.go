@@ -0,0 +1,77 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// JWKSPath is where the gateway publishes the public keys
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWK is an Ed25519 public key in the JSON Web Key format of RFC 8037
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	X         string `json:"x"`
+}
+
+// JWKS is a JSON Web Key Set document
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeyMaker is a Maker whose tokens can be verified with public keys
+type PublicKeyMaker interface {
+	Maker
+	JWKS() JWKS
+}
+
+func newJWK(keyID string, publicKey ed25519.PublicKey) JWK {
+	return JWK{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		KeyID:     keyID,
+		Algorithm: "EdDSA",
+		Use:       "sig",
+		X:         base64.RawURLEncoding.EncodeToString(publicKey),
+	}
+}
+
+// publicKeys decodes the Ed25519 keys of the set by ID, other kinds of keys are skipped
+func (jwks JWKS) publicKeys() (map[string]ed25519.PublicKey, error) {
+	publicKeys := make(map[string]ed25519.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.KeyType != "OKP" || jwk.Curve != "Ed25519" {
+			continue
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key %q", jwk.KeyID)
+		}
+
+		publicKeys[jwk.KeyID] = ed25519.PublicKey(x)
+	}
+
+	return publicKeys, nil
+}
+
+// JWKSHandler serves the public keys of maker, the set is empty when its tokens use a shared secret
+func JWKSHandler(maker Maker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwks := JWKS{Keys: []JWK{}}
+		if publicKeyMaker, ok := maker.(PublicKeyMaker); ok {
+			jwks = publicKeyMaker.JWKS()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(jwks)
+	})
+}
@@ -9,42 +9,98 @@ import (
 
 const minSecretKeySize = 32
 
-// JWTMaker is a struct that contains the secret key
+// JWTMaker is a struct that contains the keyring of the secret keys
 type JWTMaker struct {
-	secretKey string
+	keyring *Keyring
+	claims  Claims
 }
 
-// NewJWTMaker is a function that creates a new JWTMaker
+// NewJWTMaker is a function that creates a new JWTMaker, its tokens have no issuer nor audience
 func NewJWTMaker(secret string) (Maker, error) {
+	if err := checkJWTKey(secret); err != nil {
+		return nil, err
+	}
+
+	return NewJWTMakerWithKeyring(NewKeyring(secret), Claims{})
+}
+
+// NewJWTMakerWithKeyring creates a maker that signs with the active key of keyring
+// and verifies with any of its keys that is not retired
+func NewJWTMakerWithKeyring(keyring *Keyring, claims Claims) (Maker, error) {
+	if err := keyring.validate(func(key Key) error { return checkJWTKey(key.Secret) }); err != nil {
+		return nil, err
+	}
+
+	return &JWTMaker{keyring: keyring, claims: claims}, nil
+}
+
+func checkJWTKey(secret string) error {
 	if len(secret) < minSecretKeySize {
-		return nil, fmt.Errorf("invalid secret key size: must be at least %d characters", minSecretKeySize)
+		return fmt.Errorf("invalid secret key size: must be at least %d characters", minSecretKeySize)
 	}
 
-	return &JWTMaker{secret}, nil
+	return nil
 }
 
-func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+func (maker *JWTMaker) CreateToken(username string, role string, permissions []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := maker.claims.newPayload(username, role, permissions, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	key, err := maker.keyring.ActiveKey()
 	if err != nil {
 		return "", payload, err
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-	token, err := jwtToken.SignedString([]byte(maker.secretKey))
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload))
+	jwtToken.Header["kid"] = key.ID
+	token, err := jwtToken.SignedString([]byte(key.Secret))
 
 	return token, payload, err
 }
 
-// VerifyToken check if the token is valid or not
+// VerifyToken check if the token is valid or not.
+// The claims are validated by the payload rather than by the jwt package, so every maker returns the same errors.
 func (maker *JWTMaker) VerifyToken(tokenString string) (*Payload, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Payload{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(maker.secretKey), nil
-	}, jwt.WithLeeway(5*time.Second))
+	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, maker.tokenKey,
+		jwt.WithoutClaimsValidation(),
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	)
 	if err != nil {
 		return nil, err
-	} else if payload, ok := token.Claims.(*Payload); ok {
-		return payload, nil
-	} else {
+	}
+
+	claims, ok := token.Claims.(*jwtClaims)
+	if !ok {
 		return nil, jwt.ErrTokenMalformed
 	}
+
+	payload, err := claims.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := payload.Validate(maker.claims); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// tokenKey returns the secret of the key named by the kid header,
+// tokens created before keys had IDs are verified with the active key
+func (maker *JWTMaker) tokenKey(token *jwt.Token) (interface{}, error) {
+	var key Key
+	var err error
+	if keyID, ok := token.Header["kid"].(string); ok {
+		key, err = maker.keyring.Key(keyID)
+	} else {
+		key, err = maker.keyring.ActiveKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(key.Secret), nil
 }
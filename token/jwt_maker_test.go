@@ -22,8 +22,9 @@ func TestJWTMaker(t *testing.T) {
 	duration := time.Minute
 	issuedAt := time.Now()
 	role := util.DepositorRole
+	permissions := []string{PermissionAccountsRead, PermissionTransfersWrite}
 
-	token, payload, err := maker.CreateToken(username, role, duration)
+	token, payload, err := maker.CreateToken(username, role, permissions, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -34,6 +35,7 @@ func TestJWTMaker(t *testing.T) {
 
 	require.Equal(t, username, payload.Username)
 	require.Equal(t, role, payload.Role)
+	require.Equal(t, permissions, payload.Permissions)
 	require.NotZero(t, payload.ID)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, issuedAt.Add(duration), payload.ExpiredAt, time.Second)
@@ -43,14 +45,14 @@ func TestExpiredToken(t *testing.T) {
 	maker, err := NewJWTMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
 	payload, err = maker.VerifyToken(token)
 	require.Error(t, err)
-	require.ErrorContains(t, err, jwt.ErrTokenExpired.Error())
+	require.ErrorIs(t, err, ErrExpiredToken)
 	require.Nil(t, payload)
 }
 
@@ -68,13 +70,13 @@ func TestInvalidAglo(t *testing.T) {
 	maker, err := NewJWTMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
 	// Change the algorithm
-	_, err = jwt.ParseWithClaims(token, &Payload{}, func(token *jwt.Token) (interface{}, error) {
+	_, err = jwt.ParseWithClaims(token, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte("secret"), nil
 	})
 	require.Error(t, err)
@@ -92,10 +94,10 @@ func TestEmptyToken(t *testing.T) {
 }
 
 func TestInvalidJWTTokenAlgoNone(t *testing.T) {
-	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
 	require.NoError(t, err)
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, newJWTClaims(payload))
 	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
 	require.NoError(t, err)
 
@@ -0,0 +1,205 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+)
+
+// DefaultKeyID is the ID of the key built from a single configured secret
+const DefaultKeyID = "default"
+
+// keySecretSize is the size of generated secrets, the key size of PASETO and enough for JWT
+const keySecretSize = 32
+
+const keySecretAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+var (
+	// ErrUnknownKey is returned when a token is signed with a key the keyring does not have, or that was retired
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrActiveKeyRetired is returned when retiring the key used to create new tokens
+	ErrActiveKeyRetired = errors.New("cannot retire the active key")
+)
+
+// Key is a signing key of a keyring, identified in tokens by its ID.
+// Secret is used by the symmetric makers and PrivateKey by the Ed25519 ones.
+type Key struct {
+	ID         string             `json:"id"`
+	Secret     string             `json:"secret,omitempty"`
+	PrivateKey ed25519.PrivateKey `json:"private_key,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	RetiredAt  *time.Time         `json:"retired_at,omitempty"`
+}
+
+// Retired tells if tokens signed with the key are no longer accepted
+func (key Key) Retired() bool {
+	return key.RetiredAt != nil
+}
+
+// Keyring holds the keys of a maker: the active one creates tokens,
+// and every key that is not retired verifies them, so keys can be rotated without logging users out
+type Keyring struct {
+	ActiveKeyID string `json:"active_key_id"`
+	Keys        []Key  `json:"keys"`
+}
+
+// NewKeyring returns a keyring holding only secret, under DefaultKeyID
+func NewKeyring(secret string) *Keyring {
+	return &Keyring{
+		ActiveKeyID: DefaultKeyID,
+		Keys: []Key{
+			{ID: DefaultKeyID, Secret: secret, CreatedAt: time.Now()},
+		},
+	}
+}
+
+// LoadKeyring reads the keyring from file, or returns a keyring holding only secret when file is empty
+func LoadKeyring(file string, secret string) (*Keyring, error) {
+	if file == "" {
+		return NewKeyring(secret), nil
+	}
+
+	return ReadKeyringFile(file)
+}
+
+// ReadKeyringFile reads a keyring written by WriteFile
+func ReadKeyringFile(file string) (*Keyring, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read keyring: %w", err)
+	}
+
+	keyring := &Keyring{}
+	if err := json.Unmarshal(data, keyring); err != nil {
+		return nil, fmt.Errorf("cannot parse keyring: %w", err)
+	}
+
+	if _, err := keyring.ActiveKey(); err != nil {
+		return nil, err
+	}
+
+	return keyring, nil
+}
+
+// WriteFile saves the keyring to file, readable by its owner only since it holds the secrets
+func (keyring *Keyring) WriteFile(file string) error {
+	data, err := json.MarshalIndent(keyring, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0600)
+}
+
+// ActiveKey returns the key used to create new tokens
+func (keyring *Keyring) ActiveKey() (Key, error) {
+	key, err := keyring.Key(keyring.ActiveKeyID)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid active key %q: %w", keyring.ActiveKeyID, err)
+	}
+
+	return key, nil
+}
+
+// Key returns the key with the given ID, as long as it is not retired
+func (keyring *Keyring) Key(id string) (Key, error) {
+	for _, key := range keyring.Keys {
+		if key.ID == id {
+			if key.Retired() {
+				return Key{}, ErrUnknownKey
+			}
+			return key, nil
+		}
+	}
+
+	return Key{}, ErrUnknownKey
+}
+
+// AddKey generates a new key with a random secret and Ed25519 key pair, and makes it the active one.
+// Tokens signed with the previous active key are still accepted until it is retired.
+func (keyring *Keyring) AddKey(id string) (Key, error) {
+	for _, key := range keyring.Keys {
+		if key.ID == id {
+			return Key{}, fmt.Errorf("key %q already exists", id)
+		}
+	}
+
+	secret, err := randomSecret(keySecretSize)
+	if err != nil {
+		return Key{}, err
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, fmt.Errorf("cannot generate key pair: %w", err)
+	}
+
+	key := Key{
+		ID:         id,
+		Secret:     secret,
+		PrivateKey: privateKey,
+		CreatedAt:  time.Now(),
+	}
+	keyring.Keys = append(keyring.Keys, key)
+	keyring.ActiveKeyID = key.ID
+
+	return key, nil
+}
+
+// RetireKey stops accepting tokens signed with the key, which should happen
+// once the tokens it signed have expired
+func (keyring *Keyring) RetireKey(id string) error {
+	if id == keyring.ActiveKeyID {
+		return ErrActiveKeyRetired
+	}
+
+	for i := range keyring.Keys {
+		if keyring.Keys[i].ID == id {
+			if !keyring.Keys[i].Retired() {
+				retiredAt := time.Now()
+				keyring.Keys[i].RetiredAt = &retiredAt
+			}
+			return nil
+		}
+	}
+
+	return ErrUnknownKey
+}
+
+// validate checks the keys that are not retired
+func (keyring *Keyring) validate(checkKey func(key Key) error) error {
+	if _, err := keyring.ActiveKey(); err != nil {
+		return err
+	}
+
+	for _, key := range keyring.Keys {
+		if key.Retired() {
+			continue
+		}
+
+		if err := checkKey(key); err != nil {
+			return fmt.Errorf("key %q: %w", key.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func randomSecret(size int) (string, error) {
+	secret := make([]byte, size)
+	alphabetSize := big.NewInt(int64(len(keySecretAlphabet)))
+	for i := range secret {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("cannot generate secret: %w", err)
+		}
+		secret[i] = keySecretAlphabet[n.Int64()]
+	}
+
+	return string(secret), nil
+}
@@ -0,0 +1,80 @@
+package token
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	newMakers := map[string]func(keyring *Keyring, claims Claims) (Maker, error){
+		"Paseto": NewPasetoMakerWithKeyring,
+		"JWT":    NewJWTMakerWithKeyring,
+	}
+
+	for name, newMaker := range newMakers {
+		t.Run(name, func(t *testing.T) {
+			keyring := NewKeyring(util.RandomString(32))
+			maker, err := newMaker(keyring, Claims{})
+			require.NoError(t, err)
+
+			oldToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+			require.NoError(t, err)
+
+			key, err := keyring.AddKey("next")
+			require.NoError(t, err)
+			require.Equal(t, key.ID, keyring.ActiveKeyID)
+			require.Len(t, key.Secret, keySecretSize)
+
+			maker, err = newMaker(keyring, Claims{})
+			require.NoError(t, err)
+
+			// tokens of the previous key stay valid after the rotation
+			_, err = maker.VerifyToken(oldToken)
+			require.NoError(t, err)
+
+			newToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+			require.NoError(t, err)
+
+			// a maker that does not know the new key rejects its tokens
+			oldMaker, err := newMaker(NewKeyring(keyring.Keys[0].Secret), Claims{})
+			require.NoError(t, err)
+			_, err = oldMaker.VerifyToken(newToken)
+			require.ErrorContains(t, err, ErrUnknownKey.Error())
+
+			require.ErrorIs(t, keyring.RetireKey("next"), ErrActiveKeyRetired)
+			require.NoError(t, keyring.RetireKey(DefaultKeyID))
+
+			_, err = maker.VerifyToken(oldToken)
+			require.ErrorContains(t, err, ErrUnknownKey.Error())
+
+			_, err = maker.VerifyToken(newToken)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestKeyringFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keyring.json")
+
+	_, err := ReadKeyringFile(file)
+	require.Error(t, err)
+
+	keyring := NewKeyring(util.RandomString(32))
+	_, err = keyring.AddKey("next")
+	require.NoError(t, err)
+	require.NoError(t, keyring.RetireKey(DefaultKeyID))
+	require.NoError(t, keyring.WriteFile(file))
+
+	loaded, err := LoadKeyring(file, "")
+	require.NoError(t, err)
+	require.Equal(t, "next", loaded.ActiveKeyID)
+	require.Len(t, loaded.Keys, 2)
+	require.True(t, loaded.Keys[0].Retired())
+
+	_, err = loaded.Key(DefaultKeyID)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
@@ -1,11 +1,39 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of makers, as set by TOKEN_MAKER
+const (
+	MakerPaseto       = "paseto"
+	MakerJWT          = "jwt"
+	MakerPasetoPublic = "paseto_public"
+	MakerJWTEdDSA     = "jwt_eddsa"
+)
 
 type Maker interface {
-	// Create a new token for a specific username and duration
-	CreateToken(username string, role string, duration time.Duration) (string, *Payload, error)
+	// Create a new token for a specific username, granting permissions, and duration
+	CreateToken(username string, role string, permissions []string, duration time.Duration) (string, *Payload, error)
 
 	// VerifyToken check if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
+
+// NewMaker creates the kind of maker with the keys of keyring and the claims of its tokens,
+// a PASETO v2.local maker when kind is empty
+func NewMaker(kind string, keyring *Keyring, claims Claims) (Maker, error) {
+	switch kind {
+	case MakerPaseto, "":
+		return NewPasetoMakerWithKeyring(keyring, claims)
+	case MakerJWT:
+		return NewJWTMakerWithKeyring(keyring, claims)
+	case MakerPasetoPublic:
+		return NewEd25519Maker(FormatPaseto, keyring, claims)
+	case MakerJWTEdDSA:
+		return NewEd25519Maker(FormatJWT, keyring, claims)
+	default:
+		return nil, fmt.Errorf("unsupported token maker %q", kind)
+	}
+}
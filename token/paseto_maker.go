@@ -8,46 +8,96 @@ import (
 )
 
 type PasetoMaker struct {
-	paseto       *paseto.V2
-	symetrictKey []byte
+	paseto  *paseto.V2
+	keyring *Keyring
+	claims  Claims
+}
+
+// pasetoFooter is the unencrypted footer of the tokens, it tells which key encrypted them
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
 }
 
 func NewPasetoMaker(symetricKey string) (Maker, error) {
-	if len(symetricKey) != chacha20poly1305.KeySize {
-		return nil, paseto.ErrIncorrectTokenFormat
+	if err := checkPasetoKey(symetricKey); err != nil {
+		return nil, err
+	}
+
+	return NewPasetoMakerWithKeyring(NewKeyring(symetricKey), Claims{})
+}
+
+// NewPasetoMakerWithKeyring creates a maker that encrypts with the active key of keyring
+// and decrypts with any of its keys that is not retired
+func NewPasetoMakerWithKeyring(keyring *Keyring, claims Claims) (Maker, error) {
+	if err := keyring.validate(func(key Key) error { return checkPasetoKey(key.Secret) }); err != nil {
+		return nil, err
 	}
 
 	maker := &PasetoMaker{
-		paseto:       paseto.NewV2(),
-		symetrictKey: []byte(symetricKey),
+		paseto:  paseto.NewV2(),
+		keyring: keyring,
+		claims:  claims,
 	}
 
 	return maker, nil
 }
 
+func checkPasetoKey(symetricKey string) error {
+	if len(symetricKey) != chacha20poly1305.KeySize {
+		return paseto.ErrIncorrectTokenFormat
+	}
+
+	return nil
+}
+
 // Create a new token for a specific username and duration
-func (maker *PasetoMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, role, duration)
+func (maker *PasetoMaker) CreateToken(username string, role string, permissions []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := maker.claims.newPayload(username, role, permissions, duration)
 	if err != nil {
 		return "", payload, err
 	}
 
-	token, err := maker.paseto.Encrypt(maker.symetrictKey, payload, nil)
+	key, err := maker.keyring.ActiveKey()
+	if err != nil {
+		return "", payload, err
+	}
+
+	token, err := maker.paseto.Encrypt([]byte(key.Secret), payload, pasetoFooter{KeyID: key.ID})
 	return token, payload, err
 }
 
 // VerifyToken check if the token is valid or not
 func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+	key, err := maker.tokenKey(token)
+	if err != nil {
+		return nil, err
+	}
+
 	payload := &Payload{}
-	err := maker.paseto.Decrypt(token, maker.symetrictKey, payload, nil)
+	err = maker.paseto.Decrypt(token, []byte(key.Secret), payload, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = payload.Valid()
+	err = payload.Validate(maker.claims)
 	if err != nil {
 		return nil, err
 	}
 
 	return payload, nil
 }
+
+// tokenKey returns the key named in the footer of token,
+// tokens created before keys had IDs are decrypted with the active key
+func (maker *PasetoMaker) tokenKey(token string) (Key, error) {
+	var footer pasetoFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return Key{}, err
+	}
+
+	if footer.KeyID == "" {
+		return maker.keyring.ActiveKey()
+	}
+
+	return maker.keyring.Key(footer.KeyID)
+}
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/leedrum/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -23,8 +22,9 @@ func TestPasetoMaker(t *testing.T) {
 	duration := time.Minute
 	issuedAt := time.Now()
 	role := util.DepositorRole
+	permissions := []string{PermissionAccountsRead, PermissionTransfersWrite}
 
-	token, payload, err := maker.CreateToken(username, role, duration)
+	token, payload, err := maker.CreateToken(username, role, permissions, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -35,6 +35,7 @@ func TestPasetoMaker(t *testing.T) {
 
 	require.Equal(t, username, payload.Username)
 	require.Equal(t, role, payload.Role)
+	require.Equal(t, permissions, payload.Permissions)
 	require.NotZero(t, payload.ID)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, issuedAt.Add(duration), payload.ExpiredAt, time.Second)
@@ -44,13 +45,13 @@ func TestExpiredPasetoToken(t *testing.T) {
 	maker, err := NewPasetoMaker(util.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
 	payload, err = maker.VerifyToken(token)
 	require.Error(t, err)
-	require.ErrorContains(t, err, jwt.ErrTokenExpired.Error())
+	require.ErrorIs(t, err, ErrExpiredToken)
 	require.Nil(t, payload)
 }
@@ -2,7 +2,6 @@ package token
 
 import (
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,76 +10,151 @@ import (
 
 var ErrInvalidToken = errors.New("token is invalid")
 
+// Errors returned when the registered claims of a token are not valid
+var (
+	ErrExpiredToken     = errors.New("token has expired")
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+	ErrInvalidAudience  = errors.New("token has an invalid audience")
+	ErrInvalidIssuer    = errors.New("token has an invalid issuer")
+)
+
+// leeway tolerates the clock skew between the servers creating and verifying tokens
+const leeway = 5 * time.Second
+
+// Claims are the issuer and audience a maker sets on the tokens it creates and requires on the ones it verifies
+type Claims struct {
+	Issuer   string
+	Audience string
+}
+
+// Payload is the content of a token. Its JSON form, used by PASETO tokens, names the registered claims
+// of the PASETO spec with their times in RFC 3339, JWT tokens carry it as jwtClaims instead.
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
+	ID        uuid.UUID `json:"jti"`
+	Username  string    `json:"sub"`
 	Role      string    `json:"role"`
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	Issuer    string    `json:"iss,omitempty"`
+	Audience  string    `json:"aud,omitempty"`
+	IssuedAt  time.Time `json:"iat"`
+	NotBefore time.Time `json:"nbf"`
+	ExpiredAt time.Time `json:"exp"`
+	// Permissions are the scopes granted to the token, they are checked instead of the role
+	Permissions []string `json:"permissions"`
 }
 
-func NewPayload(username string, role string, duration time.Duration) (*Payload, error) {
+func NewPayload(username string, role string, permissions []string, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	payload := &Payload{
-		ID:        tokenID,
-		Username:  username,
-		Role:      role,
-		IssuedAt:  time.Now(),
-		ExpiredAt: time.Now().Add(duration),
+		ID:          tokenID,
+		Username:    username,
+		Role:        role,
+		Permissions: permissions,
+		IssuedAt:    now,
+		NotBefore:   now,
+		ExpiredAt:   now.Add(duration),
 	}
 
 	return payload, nil
 }
 
-func (payload *Payload) GetExpirationTime() (*jwt.NumericDate, error) {
-	if payload.ExpiredAt.IsZero() {
-		return nil, fmt.Errorf("missing expired at: %v", payload.ExpiredAt)
+// newPayload creates the payload of a token carrying the claims
+func (claims Claims) newPayload(username string, role string, permissions []string, duration time.Duration) (*Payload, error) {
+	payload, err := NewPayload(username, role, permissions, duration)
+	if err != nil {
+		return nil, err
 	}
 
-	return &jwt.NumericDate{
-		Time: payload.ExpiredAt,
-	}, nil
+	payload.Issuer = claims.Issuer
+	payload.Audience = claims.Audience
+	return payload, nil
 }
 
-func (payload *Payload) GetID() (string, error) {
-	return payload.ID.String(), nil
+// jwtClaims is the payload of a JWT token, with the registered claims of RFC 7519 and their times in NumericDate
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
 }
 
-func (payload *Payload) GetIssuedAt() (*jwt.NumericDate, error) {
-	if payload.IssuedAt.IsZero() {
-		return nil, fmt.Errorf("missing issued at: %v", payload.IssuedAt)
+func newJWTClaims(payload *Payload) *jwtClaims {
+	claims := &jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			Subject:   payload.Username,
+			Issuer:    payload.Issuer,
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			NotBefore: jwt.NewNumericDate(payload.NotBefore),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+		Role:        payload.Role,
+		Permissions: payload.Permissions,
 	}
 
-	return &jwt.NumericDate{
-		Time: payload.IssuedAt,
-	}, nil
-}
+	if payload.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{payload.Audience}
+	}
 
-func (payload *Payload) GetIssuer() (string, error) {
-	return payload.Username, nil
+	return claims
 }
 
-func (payload *Payload) GetNotBefore() (*jwt.NumericDate, error) {
-	return &jwt.NumericDate{
-		Time: time.Now(),
-	}, nil
-}
+// payload converts the claims of a verified JWT token, the times it misses are left zero
+func (claims *jwtClaims) payload() (*Payload, error) {
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
 
-func (payload *Payload) GetSubject() (string, error) {
-	return payload.Username, nil
-}
+	// the tokens of the makers are meant for a single audience
+	if len(claims.Audience) > 1 {
+		return nil, ErrInvalidAudience
+	}
 
-func (payload *Payload) GetAudience() (jwt.ClaimStrings, error) {
-	return jwt.ClaimStrings{}, nil
+	payload := &Payload{
+		ID:          tokenID,
+		Username:    claims.Subject,
+		Role:        claims.Role,
+		Issuer:      claims.Issuer,
+		Permissions: claims.Permissions,
+	}
+
+	if len(claims.Audience) == 1 {
+		payload.Audience = claims.Audience[0]
+	}
+	if claims.IssuedAt != nil {
+		payload.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.NotBefore != nil {
+		payload.NotBefore = claims.NotBefore.Time
+	}
+	if claims.ExpiresAt != nil {
+		payload.ExpiredAt = claims.ExpiresAt.Time
+	}
+
+	return payload, nil
 }
 
-func (p *Payload) Valid() error {
-	if time.Now().After(p.ExpiredAt) {
-		return jwt.ErrTokenExpired
+// Validate checks that the token is in its validity period and was issued by and for the expected parties
+func (payload *Payload) Validate(claims Claims) error {
+	now := time.Now()
+	if now.After(payload.ExpiredAt.Add(leeway)) {
+		return ErrExpiredToken
+	}
+
+	if now.Before(payload.NotBefore.Add(-leeway)) {
+		return ErrTokenNotValidYet
+	}
+
+	if payload.Issuer != claims.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	if payload.Audience != claims.Audience {
+		return ErrInvalidAudience
 	}
 
 	return nil
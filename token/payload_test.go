@@ -0,0 +1,183 @@
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leedrum/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadValidate(t *testing.T) {
+	claims := Claims{Issuer: "simplebank", Audience: "simplebank"}
+
+	testCases := []struct {
+		name        string
+		setup       func(payload *Payload)
+		expectedErr error
+	}{
+		{
+			name:  "OK",
+			setup: func(payload *Payload) {},
+		},
+		{
+			name: "WithinLeeway",
+			setup: func(payload *Payload) {
+				payload.NotBefore = time.Now().Add(leeway / 2)
+				payload.ExpiredAt = time.Now().Add(-leeway / 2)
+			},
+		},
+		{
+			name: "Expired",
+			setup: func(payload *Payload) {
+				payload.ExpiredAt = time.Now().Add(-time.Minute)
+			},
+			expectedErr: ErrExpiredToken,
+		},
+		{
+			name: "NotValidYet",
+			setup: func(payload *Payload) {
+				payload.NotBefore = time.Now().Add(time.Minute)
+			},
+			expectedErr: ErrTokenNotValidYet,
+		},
+		{
+			name: "WrongIssuer",
+			setup: func(payload *Payload) {
+				payload.Issuer = "other"
+			},
+			expectedErr: ErrInvalidIssuer,
+		},
+		{
+			name: "WrongAudience",
+			setup: func(payload *Payload) {
+				payload.Audience = "other"
+			},
+			expectedErr: ErrInvalidAudience,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := claims.newPayload(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+			require.NoError(t, err)
+
+			tc.setup(payload)
+			err = payload.Validate(claims)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestMakerClaims(t *testing.T) {
+	claims := Claims{Issuer: "simplebank", Audience: "simplebank"}
+
+	newMakers := map[string]func(keyring *Keyring, claims Claims) (Maker, error){
+		"Paseto": NewPasetoMakerWithKeyring,
+		"JWT":    NewJWTMakerWithKeyring,
+	}
+
+	for name, newMaker := range newMakers {
+		t.Run(name, func(t *testing.T) {
+			keyring := NewKeyring(util.RandomString(32))
+			maker, err := newMaker(keyring, claims)
+			require.NoError(t, err)
+
+			token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, claims.Issuer, payload.Issuer)
+			require.Equal(t, claims.Audience, payload.Audience)
+			require.Equal(t, payload.IssuedAt, payload.NotBefore)
+
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, claims.Issuer, payload.Issuer)
+			require.Equal(t, claims.Audience, payload.Audience)
+			require.WithinDuration(t, payload.IssuedAt, payload.NotBefore, time.Second)
+
+			// makers sharing the keys reject the tokens issued by or for someone else
+			otherIssuer, err := newMaker(keyring, Claims{Issuer: "other", Audience: claims.Audience})
+			require.NoError(t, err)
+			_, err = otherIssuer.VerifyToken(token)
+			require.ErrorIs(t, err, ErrInvalidIssuer)
+
+			otherAudience, err := newMaker(keyring, Claims{Issuer: claims.Issuer, Audience: "other"})
+			require.NoError(t, err)
+			_, err = otherAudience.VerifyToken(token)
+			require.ErrorIs(t, err, ErrInvalidAudience)
+		})
+	}
+}
+
+func TestRegisteredClaims(t *testing.T) {
+	claims := Claims{Issuer: "simplebank", Audience: "simplebank"}
+
+	requireClaims := func(t *testing.T, raw map[string]any, payload *Payload) {
+		require.Equal(t, payload.ID.String(), raw["jti"])
+		require.Equal(t, payload.Username, raw["sub"])
+		require.Equal(t, claims.Issuer, raw["iss"])
+		require.Equal(t, util.DepositorRole, raw["role"])
+	}
+
+	t.Run("JWT", func(t *testing.T) {
+		keyring := NewKeyring(util.RandomString(32))
+		maker, err := NewJWTMakerWithKeyring(keyring, claims)
+		require.NoError(t, err)
+
+		token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		require.Len(t, parts, 3)
+		data, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(data, &raw))
+		requireClaims(t, raw, payload)
+		require.Equal(t, []any{claims.Audience}, raw["aud"])
+
+		// times are NumericDate, in seconds since the epoch
+		require.Equal(t, float64(payload.IssuedAt.Unix()), raw["iat"])
+		require.Equal(t, float64(payload.NotBefore.Unix()), raw["nbf"])
+		require.Equal(t, float64(payload.ExpiredAt.Unix()), raw["exp"])
+	})
+
+	t.Run("PasetoV4", func(t *testing.T) {
+		maker, err := NewEd25519Maker(FormatPaseto, newEd25519Keyring(t, "k1"), claims)
+		require.NoError(t, err)
+
+		token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, nil, time.Minute)
+		require.NoError(t, err)
+
+		message, _, _, err := parsePasetoV4(token)
+		require.NoError(t, err)
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(message, &raw))
+		requireClaims(t, raw, payload)
+		require.Equal(t, claims.Audience, raw["aud"])
+
+		// times are RFC 3339 strings
+		for name, expected := range map[string]time.Time{
+			"iat": payload.IssuedAt,
+			"nbf": payload.NotBefore,
+			"exp": payload.ExpiredAt,
+		} {
+			value, ok := raw[name].(string)
+			require.True(t, ok, name)
+			actual, err := time.Parse(time.RFC3339, value)
+			require.NoError(t, err)
+			require.True(t, expected.Equal(actual), name)
+		}
+	})
+}
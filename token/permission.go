@@ -0,0 +1,69 @@
+package token
+
+// Permissions carried by access tokens, as resource:action scopes.
+// The admin scope of a resource grants access to the resources of other users.
+const (
+	PermissionAccountsRead   = "accounts:read"
+	PermissionAccountsWrite  = "accounts:write"
+	PermissionAccountsAdmin  = "accounts:admin"
+	PermissionTransfersRead  = "transfers:read"
+	PermissionTransfersWrite = "transfers:write"
+	PermissionTransfersAdmin = "transfers:admin"
+	PermissionSettingsRead   = "settings:read"
+	PermissionSettingsAdmin  = "settings:admin"
+	PermissionSessionsRead   = "sessions:read"
+	PermissionSessionsWrite  = "sessions:write"
+	PermissionUsersWrite     = "users:write"
+	PermissionUsersAdmin     = "users:admin"
+	PermissionTokensWrite    = "tokens:write"
+)
+
+// AllPermissions lists every permission a token can carry
+var AllPermissions = []string{
+	PermissionAccountsRead,
+	PermissionAccountsWrite,
+	PermissionAccountsAdmin,
+	PermissionTransfersRead,
+	PermissionTransfersWrite,
+	PermissionTransfersAdmin,
+	PermissionSettingsRead,
+	PermissionSettingsAdmin,
+	PermissionSessionsRead,
+	PermissionSessionsWrite,
+	PermissionUsersWrite,
+	PermissionUsersAdmin,
+	PermissionTokensWrite,
+}
+
+// IsSupportedPermission tells if the permission is one tokens can carry
+func IsSupportedPermission(permission string) bool {
+	for _, supported := range AllPermissions {
+		if permission == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPermission tells if the token grants the permission
+func (payload *Payload) HasPermission(permission string) bool {
+	for _, granted := range payload.Permissions {
+		if permission == granted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPermissions tells if the token grants all the permissions, so a token down-scoped to them can be issued
+func (payload *Payload) HasPermissions(permissions []string) bool {
+	for _, permission := range permissions {
+		if !payload.HasPermission(permission) {
+			return false
+		}
+	}
+
+	return true
+}
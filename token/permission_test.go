@@ -0,0 +1,27 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupportedPermission(t *testing.T) {
+	for _, permission := range AllPermissions {
+		require.True(t, IsSupportedPermission(permission))
+	}
+
+	require.False(t, IsSupportedPermission(""))
+	require.False(t, IsSupportedPermission("accounts:delete"))
+}
+
+func TestHasPermissions(t *testing.T) {
+	payload := &Payload{Permissions: []string{PermissionAccountsRead, PermissionTransfersWrite}}
+
+	require.True(t, payload.HasPermission(PermissionAccountsRead))
+	require.False(t, payload.HasPermission(PermissionAccountsWrite))
+
+	require.True(t, payload.HasPermissions(nil))
+	require.True(t, payload.HasPermissions([]string{PermissionTransfersWrite, PermissionAccountsRead}))
+	require.False(t, payload.HasPermissions([]string{PermissionAccountsRead, PermissionUsersAdmin}))
+}
@@ -0,0 +1,8 @@
+package util
+
+// Constants for the account products created by the migrations.
+// Bankers may add more, so products are validated against the database rather than this list.
+const (
+	AccountProductChecking = "checking"
+	AccountProductSavings  = "savings"
+)
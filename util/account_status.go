@@ -0,0 +1,17 @@
+package util
+
+// Constants for all supported account statuses
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+// IsSupportedAccountStatus returns true if the account status is supported
+func IsSupportedAccountStatus(status string) bool {
+	switch status {
+	case AccountStatusActive, AccountStatusFrozen, AccountStatusClosed:
+		return true
+	}
+	return false
+}
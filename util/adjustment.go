@@ -0,0 +1,18 @@
+package util
+
+// Constants for all supported balance adjustment reasons
+const (
+	AdjustmentCorrection = "correction"
+	AdjustmentGoodwill   = "goodwill"
+	AdjustmentChargeback = "chargeback"
+	AdjustmentFeeRefund  = "fee_refund"
+)
+
+// IsSupportedAdjustmentReason returns true if the reason code is supported
+func IsSupportedAdjustmentReason(reason string) bool {
+	switch reason {
+	case AdjustmentCorrection, AdjustmentGoodwill, AdjustmentChargeback, AdjustmentFeeRefund:
+		return true
+	}
+	return false
+}
@@ -9,15 +9,32 @@ import (
 // Config is the configuration of the application
 // It should be populated by the environment variables
 type Config struct {
-	Environment          string        `mapstructure:"ENVIRONMENT"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	Environment                    string        `mapstructure:"ENVIRONMENT"`
+	DBSource                       string        `mapstructure:"DB_SOURCE"`
+	MigrationURL                   string        `mapstructure:"MIGRATION_URL"`
+	RedisAddress                   string        `mapstructure:"REDIS_ADDRESS"`
+	HTTPServerAddress              string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress              string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey              string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenKeyringFile               string        `mapstructure:"TOKEN_KEYRING_FILE"`
+	TokenMaker                     string        `mapstructure:"TOKEN_MAKER"`
+	TokenIssuer                    string        `mapstructure:"TOKEN_ISSUER"`
+	TokenAudience                  string        `mapstructure:"TOKEN_AUDIENCE"`
+	AccessTokenDuration            time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration           time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	ScopedTokenMaxDuration         time.Duration `mapstructure:"SCOPED_TOKEN_MAX_DURATION"`
+	TokenRevocationCacheTTL        time.Duration `mapstructure:"TOKEN_REVOCATION_CACHE_TTL"`
+	TokenRevocationRedis           bool          `mapstructure:"TOKEN_REVOCATION_REDIS"`
+	TokenRevocationPruneSchedule   string        `mapstructure:"TOKEN_REVOCATION_PRUNE_SCHEDULE"`
+	LedgerVerifySchedule           string        `mapstructure:"LEDGER_VERIFY_SCHEDULE"`
+	ExchangeQuoteDuration          time.Duration `mapstructure:"EXCHANGE_QUOTE_DURATION"`
+	ScheduledTransfersSchedule     string        `mapstructure:"SCHEDULED_TRANSFERS_SCHEDULE"`
+	HoldDuration                   time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldExpirySchedule             string        `mapstructure:"HOLD_EXPIRY_SCHEDULE"`
+	TransferApprovalDuration       time.Duration `mapstructure:"TRANSFER_APPROVAL_DURATION"`
+	TransferApprovalExpirySchedule string        `mapstructure:"TRANSFER_APPROVAL_EXPIRY_SCHEDULE"`
+	InterestAccrualSchedule        string        `mapstructure:"INTEREST_ACCRUAL_SCHEDULE"`
+	InterestPostingSchedule        string        `mapstructure:"INTEREST_POSTING_SCHEDULE"`
 }
 
 // LoadConfig load the configuration from the environment variables
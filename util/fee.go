@@ -0,0 +1,16 @@
+package util
+
+// Constants for the kinds of transfer a fee schedule can apply to
+const (
+	FeeTypeTransfer = "transfer"
+	FeeTypeExchange = "exchange"
+)
+
+// IsSupportedFeeType returns true if fee schedules can be set for the transfer type
+func IsSupportedFeeType(feeType string) bool {
+	switch feeType {
+	case FeeTypeTransfer, FeeTypeExchange:
+		return true
+	}
+	return false
+}
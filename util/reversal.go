@@ -0,0 +1,19 @@
+package util
+
+// Constants for all supported transfer reversal reasons
+const (
+	ReversalDuplicate      = "duplicate"
+	ReversalWrongAccount   = "wrong_account"
+	ReversalWrongAmount    = "wrong_amount"
+	ReversalFraud          = "fraud"
+	ReversalCustomerRefund = "customer_refund"
+)
+
+// IsSupportedReversalReason returns true if the reason code is supported
+func IsSupportedReversalReason(reason string) bool {
+	switch reason {
+	case ReversalDuplicate, ReversalWrongAccount, ReversalWrongAmount, ReversalFraud, ReversalCustomerRefund:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Constants for what a scheduled transfer does when the account cannot cover it
+const (
+	InsufficientFundsSkip  = "skip"
+	InsufficientFundsRetry = "retry"
+)
+
+// IsSupportedInsufficientFundsPolicy returns true if the policy is supported
+func IsSupportedInsufficientFundsPolicy(policy string) bool {
+	switch policy {
+	case InsufficientFundsSkip, InsufficientFundsRetry:
+		return true
+	}
+	return false
+}
+
+// NextRun returns the first time after `after` that a schedule fires.
+// The schedule is a standard 5-field cron expression or a descriptor such as @monthly or @every 24h,
+// always evaluated in UTC.
+func NextRun(schedule string, after time.Time) (time.Time, error) {
+	parsed, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+
+	next := parsed.Next(after.UTC())
+	if next.IsZero() {
+		return next, fmt.Errorf("schedule %q never fires", schedule)
+	}
+
+	return next, nil
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	// 09:00 on the 1st of every month
+	next, err := NextRun("0 9 1 * *", after)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC), next)
+
+	next, err = NextRun("@monthly", after)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), next)
+
+	next, err = NextRun("@every 24h", after)
+	require.NoError(t, err)
+	require.Equal(t, after.Add(24*time.Hour), next)
+
+	// other time zones are evaluated in UTC
+	next, err = NextRun("0 9 1 * *", after.In(time.FixedZone("UTC+7", 7*60*60)))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC), next)
+
+	_, err = NextRun("every monday", after)
+	require.Error(t, err)
+
+	// February 30th does not exist
+	_, err = NextRun("0 0 30 2 *", after)
+	require.Error(t, err)
+}
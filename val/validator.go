@@ -4,6 +4,12 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leedrum/simplebank/money"
+	"github.com/leedrum/simplebank/token"
+	"github.com/leedrum/simplebank/util"
 )
 
 const (
@@ -15,11 +21,19 @@ const (
 	MaxEmailLength    = 200
 	MinFullNameLength = 3
 	MaxFullNameLength = 100
+	MinPageSize       = 5
+	MaxPageSize       = 10
+
+	MaxIdempotencyKeyLength = 255
+	MaxScheduleLength       = 100
+	MaxNoteLength           = 255
+	MaxReferenceLength      = 64
 )
 
 var (
-	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
-	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidUsername  = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+	isValidFullName  = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidReference = regexp.MustCompile(`^[a-zA-Z0-9._:/-]+$`).MatchString
 )
 
 func ValidateString(str string, minLength int, maxLenth int) error {
@@ -86,3 +100,204 @@ func ValidateSecretCode(secret_code string) error {
 
 	return nil
 }
+
+func ValidateID(id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+
+	return nil
+}
+
+func ValidateAmount(amount int64) error {
+	if amount <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateCurrency(currency string) error {
+	if !util.IsSupportedCurrency(currency) {
+		return fmt.Errorf("unsupported currency")
+	}
+
+	return nil
+}
+
+func ValidatePageID(pageID int32) error {
+	if pageID < 1 {
+		return fmt.Errorf("page id must be at least 1")
+	}
+
+	return nil
+}
+
+func ValidatePageSize(pageSize int32) error {
+	if pageSize < MinPageSize || pageSize > MaxPageSize {
+		return fmt.Errorf("page size must be between %d and %d", MinPageSize, MaxPageSize)
+	}
+
+	return nil
+}
+
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", MaxIdempotencyKeyLength)
+	}
+
+	return nil
+}
+
+func ValidateExchangeRate(rate int64) error {
+	if rate <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateQuoteID(quoteID string) error {
+	if _, err := uuid.Parse(quoteID); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+
+	return nil
+}
+
+func ValidateSessionID(sessionID string) error {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+
+	return nil
+}
+
+// ValidateAmountDecimal checks that value is a positive decimal amount with no more decimals than currency allows
+func ValidateAmountDecimal(value string, currency string) error {
+	amount, err := money.Parse(value, currency)
+	if err != nil {
+		return err
+	}
+
+	return ValidateAmount(amount.Amount)
+}
+
+// ValidateSchedule checks that schedule is a cron expression or descriptor that fires at least once
+func ValidateSchedule(schedule string) error {
+	if err := ValidateString(schedule, 1, MaxScheduleLength); err != nil {
+		return err
+	}
+
+	if _, err := util.NextRun(schedule, time.Now()); err != nil {
+		return fmt.Errorf("must be a cron expression such as \"0 9 1 * *\" or a descriptor such as @monthly or @every 24h")
+	}
+
+	return nil
+}
+
+func ValidateInsufficientFundsPolicy(policy string) error {
+	if !util.IsSupportedInsufficientFundsPolicy(policy) {
+		return fmt.Errorf("must be %s or %s", util.InsufficientFundsSkip, util.InsufficientFundsRetry)
+	}
+
+	return nil
+}
+
+func ValidateReversalReason(reason string) error {
+	if !util.IsSupportedReversalReason(reason) {
+		return fmt.Errorf("unsupported reversal reason")
+	}
+
+	return nil
+}
+
+func ValidateAdjustmentReason(reason string) error {
+	if !util.IsSupportedAdjustmentReason(reason) {
+		return fmt.Errorf("must be %s, %s, %s or %s", util.AdjustmentCorrection, util.AdjustmentGoodwill, util.AdjustmentChargeback, util.AdjustmentFeeRefund)
+	}
+
+	return nil
+}
+
+// ValidateAdjustmentAmount checks the signed amount of a balance adjustment
+func ValidateAdjustmentAmount(amount int64) error {
+	if amount == 0 {
+		return fmt.Errorf("must not be 0")
+	}
+
+	return nil
+}
+
+func ValidateFeeType(feeType string) error {
+	if !util.IsSupportedFeeType(feeType) {
+		return fmt.Errorf("must be %s or %s", util.FeeTypeTransfer, util.FeeTypeExchange)
+	}
+
+	return nil
+}
+
+func ValidateAccountStatus(status string) error {
+	if !util.IsSupportedAccountStatus(status) {
+		return fmt.Errorf("must be %s, %s or %s", util.AccountStatusActive, util.AccountStatusFrozen, util.AccountStatusClosed)
+	}
+
+	return nil
+}
+
+func ValidateNote(note string) error {
+	return ValidateString(note, 0, MaxNoteLength)
+}
+
+// ValidateReference checks the id an external system gave to a deposit or withdrawal
+func ValidateReference(reference string) error {
+	if err := ValidateString(reference, 1, MaxReferenceLength); err != nil {
+		return err
+	}
+
+	if !isValidReference(reference) {
+		return fmt.Errorf("must contain only letters, digits, dots, dashes, underscores, colons or slashes")
+	}
+
+	return nil
+}
+
+// ValidatePermissions checks that permissions lists at least one permission an access token can carry
+func ValidatePermissions(permissions []string) error {
+	if len(permissions) == 0 {
+		return fmt.Errorf("must not be empty")
+	}
+
+	for _, permission := range permissions {
+		if !token.IsSupportedPermission(permission) {
+			return fmt.Errorf("unsupported permission %q", permission)
+		}
+	}
+
+	return nil
+}
+
+// ValidateScopedPermissions checks the permissions of a down-scoped token,
+// which cannot include tokens:write so it cannot mint tokens of its own
+func ValidateScopedPermissions(permissions []string) error {
+	if err := ValidatePermissions(permissions); err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if permission == token.PermissionTokensWrite {
+			return fmt.Errorf("a scoped token cannot carry %q", permission)
+		}
+	}
+
+	return nil
+}
+
+// ValidateTokenDuration checks that a token lasts a positive duration of at most maxDuration
+func ValidateTokenDuration(duration time.Duration, maxDuration time.Duration) error {
+	if duration <= 0 || duration > maxDuration {
+		return fmt.Errorf("must be positive and at most %s", maxDuration)
+	}
+
+	return nil
+}
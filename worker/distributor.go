@@ -12,6 +12,11 @@ type TaskDistributor interface {
 		payload PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendTransferApprovalEmail(
+		ctx context.Context,
+		payload PayloadSendTransferApprovalEmail,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -6,6 +6,7 @@ import (
 	"github.com/hibiken/asynq"
 	db "github.com/leedrum/simplebank/db/sqlc"
 	"github.com/leedrum/simplebank/mail"
+	"github.com/leedrum/simplebank/util"
 	"github.com/rs/zerolog/log"
 )
 
@@ -17,18 +18,28 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskVerifyLedger(ctx context.Context, task *asynq.Task) error
+	ProcessTaskRunScheduledTransfers(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReleaseExpiredHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendTransferApprovalEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireTransferApprovals(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPruneRevokedTokens(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
 	server *asynq.Server
 	store  db.Store
 	mailer mail.EmailSender
+	config util.Config
 }
 
 func NewRedisTaskProcessor(
 	redisOpts asynq.RedisClientOpt,
 	store db.Store,
 	mailer mail.EmailSender,
+	config util.Config,
 ) TaskProcessor {
 	server := asynq.NewServer(redisOpts, asynq.Config{
 		Queues: map[string]int{
@@ -49,12 +60,21 @@ func NewRedisTaskProcessor(
 		server: server,
 		store:  store,
 		mailer: mailer,
+		config: config,
 	}
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TaskTypeSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskTypeVerifyLedger, processor.ProcessTaskVerifyLedger)
+	mux.HandleFunc(TaskTypeRunScheduledTransfers, processor.ProcessTaskRunScheduledTransfers)
+	mux.HandleFunc(TaskTypeReleaseExpiredHolds, processor.ProcessTaskReleaseExpiredHolds)
+	mux.HandleFunc(TaskTypeSendTransferApprovalEmail, processor.ProcessTaskSendTransferApprovalEmail)
+	mux.HandleFunc(TaskTypeExpireTransferApprovals, processor.ProcessTaskExpireTransferApprovals)
+	mux.HandleFunc(TaskTypeAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskTypePostInterest, processor.ProcessTaskPostInterest)
+	mux.HandleFunc(TaskTypePruneRevokedTokens, processor.ProcessTaskPruneRevokedTokens)
 
 	return processor.server.Start(mux)
 }
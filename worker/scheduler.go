@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/leedrum/simplebank/util"
+)
+
+// NewTaskScheduler creates a scheduler that periodically enqueues the maintenance tasks,
+// which are then picked up by the task processor like any other task
+func NewTaskScheduler(redisOpt asynq.RedisConnOpt, config util.Config) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	_, err := scheduler.Register(
+		config.LedgerVerifySchedule,
+		asynq.NewTask(TaskTypeVerifyLedger, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypeVerifyLedger, err)
+	}
+
+	_, err = scheduler.Register(
+		config.ScheduledTransfersSchedule,
+		asynq.NewTask(TaskTypeRunScheduledTransfers, nil),
+		asynq.Queue(QueueCritical),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypeRunScheduledTransfers, err)
+	}
+
+	_, err = scheduler.Register(
+		config.HoldExpirySchedule,
+		asynq.NewTask(TaskTypeReleaseExpiredHolds, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypeReleaseExpiredHolds, err)
+	}
+
+	_, err = scheduler.Register(
+		config.TransferApprovalExpirySchedule,
+		asynq.NewTask(TaskTypeExpireTransferApprovals, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypeExpireTransferApprovals, err)
+	}
+
+	_, err = scheduler.Register(
+		config.InterestAccrualSchedule,
+		asynq.NewTask(TaskTypeAccrueInterest, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypeAccrueInterest, err)
+	}
+
+	_, err = scheduler.Register(
+		config.InterestPostingSchedule,
+		asynq.NewTask(TaskTypePostInterest, nil),
+		asynq.Queue(QueueCritical),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypePostInterest, err)
+	}
+
+	_, err = scheduler.Register(
+		config.TokenRevocationPruneSchedule,
+		asynq.NewTask(TaskTypePruneRevokedTokens, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot schedule %s: %w", TaskTypePruneRevokedTokens, err)
+	}
+
+	return scheduler, nil
+}
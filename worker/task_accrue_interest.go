@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeAccrueInterest = "task:accrue_interest"
+
+// interestBatchSize is the number of accounts listed at a time by the interest tasks
+const interestBatchSize = 100
+
+// ProcessTaskAccrueInterest accrues the interest of every interest-bearing account for the previous UTC day.
+// The days missed since the last accrual, when the task did not run, are accrued first.
+// Accounts that already accrued for a day are skipped, so the task can be run again after a failure.
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+
+	// the last day is accrued again, in case the run that accrued it stopped halfway
+	from, err := processor.store.GetLastInterestAccrualDate(ctx, yesterday)
+	if err != nil {
+		return fmt.Errorf("failed to get the last interest accrual date: %w", err)
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+
+	accrued := 0
+	for date := from; !date.After(yesterday); date = date.AddDate(0, 0, 1) {
+		count, err := processor.accrueInterest(ctx, date)
+		if err != nil {
+			return err
+		}
+		accrued += count
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("from", from.Format(time.DateOnly)).
+		Str("to", yesterday.Format(time.DateOnly)).
+		Int("accrued", accrued).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeAccrueInterest)
+
+	return nil
+}
+
+// accrueInterest accrues the interest of every interest-bearing account that existed on a UTC day,
+// and returns how many accounts accrued
+func (processor *RedisTaskProcessor) accrueInterest(ctx context.Context, date time.Time) (int, error) {
+	accrued := 0
+	var afterID int64
+	for {
+		accountIDs, err := processor.store.ListAccountsForInterestAccrual(ctx, db.ListAccountsForInterestAccrualParams{
+			Before:     date.AddDate(0, 0, 1),
+			AfterID:    afterID,
+			LimitCount: interestBatchSize,
+		})
+		if err != nil {
+			return accrued, fmt.Errorf("failed to list accounts for interest accrual: %w", err)
+		}
+
+		for _, accountID := range accountIDs {
+			_, err := processor.store.AccrueInterest(ctx, db.AccrueInterestParams{
+				AccountID: accountID,
+				Date:      date,
+			})
+			if errors.Is(err, db.ErrInterestAlreadyAccrued) {
+				continue
+			}
+			if err != nil {
+				log.Error().Err(err).Int64("account_id", accountID).Str("date", date.Format(time.DateOnly)).Msg("cannot accrue interest")
+				continue
+			}
+			accrued++
+		}
+
+		if len(accountIDs) < interestBatchSize {
+			return accrued, nil
+		}
+		afterID = accountIDs[len(accountIDs)-1]
+	}
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeExpireTransferApprovals = "task:expire_transfer_approvals"
+
+func (processor *RedisTaskProcessor) ProcessTaskExpireTransferApprovals(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	approvals, err := processor.store.ExpireTransferApprovalsTx(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to expire transfer approvals: %w", err)
+	}
+
+	// the approvals are already expired, so a failed email must not fail the task
+	for _, approval := range approvals {
+		if err := processor.sendTransferApprovalEmail(ctx, approval); err != nil {
+			log.Error().Err(err).Int64("transfer_approval_id", approval.ID).Msg("cannot notify expired transfer approval")
+		}
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("expired", len(approvals)).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeExpireTransferApprovals)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypePostInterest = "task:post_interest"
+
+// ProcessTaskPostInterest credits the interest accrued until the end of the previous month.
+// Accounts already posted for that month are skipped, so the task can be run again after a failure.
+func (processor *RedisTaskProcessor) ProcessTaskPostInterest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	period := thisMonth.AddDate(0, -1, 0)
+
+	posted := 0
+	var afterID int64
+	for {
+		accountIDs, err := processor.store.ListAccountsWithUnpostedInterest(ctx, db.ListAccountsWithUnpostedInterestParams{
+			Before:     thisMonth,
+			AfterID:    afterID,
+			LimitCount: interestBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+		}
+
+		for _, accountID := range accountIDs {
+			_, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+				AccountID: accountID,
+				Period:    period,
+			})
+			if errors.Is(err, db.ErrInterestAlreadyPosted) {
+				continue
+			}
+			if err != nil {
+				log.Error().Err(err).Int64("account_id", accountID).Msg("cannot post interest")
+				continue
+			}
+			posted++
+		}
+
+		if len(accountIDs) < interestBatchSize {
+			break
+		}
+		afterID = accountIDs[len(accountIDs)-1]
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("period", period.Format("2006-01")).
+		Int("posted", posted).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypePostInterest)
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypePruneRevokedTokens = "task:prune_revoked_tokens"
+
+// ProcessTaskPruneRevokedTokens deletes the revocations of tokens that have expired since,
+// which are rejected anyway
+func (processor *RedisTaskProcessor) ProcessTaskPruneRevokedTokens(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	pruned, err := processor.store.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prune revoked tokens: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int64("pruned", pruned).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypePruneRevokedTokens)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeReleaseExpiredHolds = "task:release_expired_holds"
+
+// expiredHoldsBatchSize bounds the holds released by one task, the rest are picked up by the next one
+const expiredHoldsBatchSize = 100
+
+func (processor *RedisTaskProcessor) ProcessTaskReleaseExpiredHolds(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	holds, err := processor.store.ListExpiredHolds(ctx, db.ListExpiredHoldsParams{
+		Now:        time.Now(),
+		LimitCount: expiredHoldsBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list expired holds: %w", err)
+	}
+
+	released := 0
+	for _, hold := range holds {
+		_, err := processor.store.ReleaseHoldTx(ctx, db.ReleaseHoldTxParams{
+			HoldID: hold.ID,
+			Status: db.HoldStatusExpired,
+		})
+		// the hold may have been captured or voided since it was listed
+		if errors.Is(err, db.ErrHoldNotActive) {
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Int64("hold_id", hold.ID).Msg("cannot release expired hold")
+			continue
+		}
+		released++
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("released", released).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeReleaseExpiredHolds)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/leedrum/simplebank/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeRunScheduledTransfers = "task:run_scheduled_transfers"
+
+const (
+	// scheduledTransfersBatchSize bounds the transfers run by one task, the rest are picked up by the next one
+	scheduledTransfersBatchSize = 100
+	scheduledTransferRetryDelay = time.Hour
+	scheduledTransferMaxRetries = 3
+)
+
+func (processor *RedisTaskProcessor) ProcessTaskRunScheduledTransfers(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	now := time.Now()
+
+	due, err := processor.store.ListDueScheduledTransfers(ctx, db.ListDueScheduledTransfersParams{
+		Now:        now,
+		LimitCount: scheduledTransfersBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	// one failing transfer must not hold back the others, so errors are recorded and logged instead of returned
+	for _, scheduled := range due {
+		processor.runScheduledTransfer(ctx, scheduled, now)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("due", len(due)).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeRunScheduledTransfers)
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) runScheduledTransfer(ctx context.Context, scheduled db.ScheduledTransfer, now time.Time) {
+	logger := log.With().Int64("scheduled_transfer_id", scheduled.ID).Logger()
+
+	nextRunAt, err := util.NextRun(scheduled.Schedule, now)
+	if err != nil {
+		processor.failScheduledTransfer(ctx, scheduled, err, pgtype.Timestamptz{}, pgtype.Bool{Bool: false, Valid: true})
+		logger.Error().Err(err).Msg("scheduled transfer deactivated")
+		return
+	}
+
+	result, err := processor.store.RunScheduledTransferTx(ctx, db.RunScheduledTransferTxParams{
+		ID:           scheduled.ID,
+		ScheduledFor: scheduled.NextRunAt,
+		NextRunAt:    nextRunAt,
+		RetryAt:      now.Add(scheduledTransferRetryDelay),
+		MaxRetries:   scheduledTransferMaxRetries,
+		// the occurrence waits for a banker as long as a transfer requested by the owner would
+		ApprovalDuration: processor.config.TransferApprovalDuration,
+	})
+	if errors.Is(err, db.ErrScheduledTransferNotDue) {
+		return
+	}
+	if err != nil {
+		processor.failScheduledTransfer(ctx, scheduled, err, pgtype.Timestamptz{Time: nextRunAt, Valid: true}, pgtype.Bool{})
+		logger.Error().Err(err).Msg("scheduled transfer failed")
+		return
+	}
+
+	logger.Info().
+		Str("status", result.Run.Status).
+		Time("next_run_at", result.ScheduledTransfer.NextRunAt).
+		Msg("scheduled transfer run")
+}
+
+// failScheduledTransfer records a failed run and moves the scheduled transfer past the occurrence,
+// so a broken transfer is not attempted again on every tick
+func (processor *RedisTaskProcessor) failScheduledTransfer(
+	ctx context.Context,
+	scheduled db.ScheduledTransfer,
+	runErr error,
+	nextRunAt pgtype.Timestamptz,
+	isActive pgtype.Bool,
+) {
+	_, err := processor.store.CreateScheduledTransferRun(ctx, db.CreateScheduledTransferRunParams{
+		ScheduledTransferID: scheduled.ID,
+		ScheduledFor:        scheduled.NextRunAt,
+		Status:              db.ScheduledRunFailed,
+		Error:               runErr.Error(),
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("scheduled_transfer_id", scheduled.ID).Msg("cannot record scheduled transfer run")
+	}
+
+	_, err = processor.store.UpdateScheduledTransfer(ctx, db.UpdateScheduledTransferParams{
+		ID:         scheduled.ID,
+		NextRunAt:  nextRunAt,
+		RetryCount: pgtype.Int4{Int32: 0, Valid: true},
+		IsActive:   isActive,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("scheduled_transfer_id", scheduled.ID).Msg("cannot update scheduled transfer")
+	}
+}
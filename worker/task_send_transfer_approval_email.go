@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/leedrum/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeSendTransferApprovalEmail = "task:send_transfer_approval_email"
+
+type PayloadSendTransferApprovalEmail struct {
+	TransferApprovalID int64 `json:"transfer_approval_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendTransferApprovalEmail(
+	ctx context.Context,
+	payload PayloadSendTransferApprovalEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	task := asynq.NewTask(TaskTypeSendTransferApprovalEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msgf("task enqueued: id=%s type=%s", info.ID, TaskTypeSendTransferApprovalEmail)
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendTransferApprovalEmail(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendTransferApprovalEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return err
+	}
+
+	approval, err := processor.store.GetTransferApproval(ctx, payload.TransferApprovalID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer approval: %w", err)
+	}
+
+	if err := processor.sendTransferApprovalEmail(ctx, approval); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeSendTransferApprovalEmail)
+
+	return nil
+}
+
+// sendTransferApprovalEmail tells the user who requested a transfer that it was rejected or expired
+func (processor *RedisTaskProcessor) sendTransferApprovalEmail(ctx context.Context, approval db.TransferApproval) error {
+	user, err := processor.store.GetUser(ctx, approval.RequestedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var outcome string
+	switch approval.Status {
+	case db.TransferApprovalRejected:
+		outcome = "was rejected by a banker"
+	case db.TransferApprovalExpired:
+		outcome = "expired before a banker reviewed it"
+	default:
+		return fmt.Errorf("no email for transfer approval [%d] with status %s: %w", approval.ID, approval.Status, asynq.SkipRetry)
+	}
+
+	subject := "Your transfer was not executed"
+	body := fmt.Sprintf(
+		"Hello, %s! Your transfer of %d %s from account %d to account %d %s, no money was moved.",
+		user.Username,
+		approval.Amount,
+		approval.Currency,
+		approval.FromAccountID,
+		approval.ToAccountID,
+		outcome,
+	)
+	if approval.ReviewNote != "" {
+		body += fmt.Sprintf(" Note: %s", approval.ReviewNote)
+	}
+
+	err = processor.mailer.SendEmail(subject, body, []string{user.Email}, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send transfer approval email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/leedrum/simplebank/ledger"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTypeVerifyLedger = "task:verify_ledger"
+
+func (processor *RedisTaskProcessor) ProcessTaskVerifyLedger(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	report, err := ledger.Verify(ctx, processor.store)
+	if err != nil {
+		return fmt.Errorf("failed to verify ledger: %w", err)
+	}
+
+	jsonReport, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	// drift is reported, not retried: running the check again will not fix the data
+	if !report.Consistent {
+		log.Error().
+			Str("type", task.Type()).
+			RawJSON("report", jsonReport).
+			Msgf("ledger drift detected: %d accounts, %d transfers", len(report.AccountDrift), len(report.UnbalancedTransfers))
+		return nil
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		RawJSON("report", jsonReport).
+		Msgf("processing task: id=%s type=%s", task.ResultWriter().TaskID(), TaskTypeVerifyLedger)
+
+	return nil
+}